@@ -23,6 +23,7 @@ const (
 	FieldTypeTime    = "sq.TimeField"
 	FieldTypeEnum    = "sq.EnumField"
 	FieldTypeBinary  = "sq.BinaryField"
+	FieldTypeUUID    = "sq.UUIDField"
 
 	FieldConstructorBoolean = "sq.NewBooleanField"
 	FieldConstructorJSON    = "sq.NewJSONField"
@@ -31,8 +32,13 @@ const (
 	FieldConstructorTime    = "sq.NewTimeField"
 	FieldConstructorEnum    = "sq.NewEnumField"
 	FieldConstructorBinary  = "sq.NewBinaryField"
+	FieldConstructorUUID    = "sq.NewUUIDField"
 )
 
+// uuidComment is the column comment tag that marks a BINARY(16) column as a
+// uuid column e.g. uuid BINARY(16) COMMENT 'sq:uuid'.
+const uuidComment = "sq:uuid"
+
 var tablesCmd = &cobra.Command{
 	Use:   "tables",
 	Short: "Generate tables from the database",
@@ -118,6 +124,7 @@ type TableField struct {
 	Name        String
 	RawType     string
 	RawTypeEx   string
+	Comment     string
 	Type        string
 	Constructor string
 }
@@ -218,7 +225,7 @@ func tablesRun(cmd *cobra.Command, args []string) error {
 
 func getTables(db *sql.DB, databaseURL string, schemas []string) ([]Table, error) {
 	// Prepare the query and args
-	query := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type, c.column_type" +
+	query := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type, c.column_type, c.column_comment" +
 		" FROM information_schema.tables AS t" +
 		" JOIN information_schema.columns AS c USING (table_schema, table_name)" +
 		" WHERE table_schema IN (?" + strings.Repeat(", ?", len(schemas)-1) + ")" +
@@ -238,8 +245,8 @@ func getTables(db *sql.DB, databaseURL string, schemas []string) ([]Table, error
 	var tableIndices = make(map[string]int)
 	var tables []Table
 	for rows.Next() {
-		var tableType, tableSchema, tableName, columnName, columnType, columnTypeEx string
-		err := rows.Scan(&tableType, &tableSchema, &tableName, &columnName, &columnType, &columnTypeEx)
+		var tableType, tableSchema, tableName, columnName, columnType, columnTypeEx, columnComment string
+		err := rows.Scan(&tableType, &tableSchema, &tableName, &columnName, &columnType, &columnTypeEx, &columnComment)
 		if err != nil {
 			return tables, err
 		}
@@ -259,6 +266,7 @@ func getTables(db *sql.DB, databaseURL string, schemas []string) ([]Table, error
 			Name:      String(columnName),
 			RawType:   columnType,
 			RawTypeEx: columnTypeEx,
+			Comment:   columnComment,
 		}
 		index := tableIndices[fullTableName]
 		tables[index].Fields = append(tables[index].Fields, field)
@@ -361,6 +369,14 @@ func (field TableField) fillInTheBlanks() TableField {
 		return field
 	}
 
+	// UUID: MySQL has no uuid type, so only BINARY(16) columns whose comment
+	// contains the uuidComment tag are treated as uuids
+	if field.RawTypeEx == "binary(16)" && strings.Contains(field.Comment, uuidComment) {
+		field.Type = FieldTypeUUID
+		field.Constructor = FieldConstructorUUID
+		return field
+	}
+
 	// Blob
 	switch field.RawType {
 	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
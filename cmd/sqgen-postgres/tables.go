@@ -16,23 +16,31 @@ import (
 
 // sq Field Types
 const (
-	FieldTypeBoolean = "sq.BooleanField"
-	FieldTypeJSON    = "sq.JSONField"
-	FieldTypeNumber  = "sq.NumberField"
-	FieldTypeString  = "sq.StringField"
-	FieldTypeTime    = "sq.TimeField"
-	FieldTypeEnum    = "sq.EnumField"
-	FieldTypeArray   = "sq.ArrayField"
-	FieldTypeBinary  = "sq.BinaryField"
-
-	FieldConstructorBoolean = "sq.NewBooleanField"
-	FieldConstructorJSON    = "sq.NewJSONField"
-	FieldConstructorNumber  = "sq.NewNumberField"
-	FieldConstructorString  = "sq.NewStringField"
-	FieldConstructorTime    = "sq.NewTimeField"
-	FieldConstructorEnum    = "sq.NewEnumField"
-	FieldConstructorArray   = "sq.NewArrayField"
-	FieldConstructorBinary  = "sq.NewBinaryField"
+	FieldTypeBoolean  = "sq.BooleanField"
+	FieldTypeJSON     = "sq.JSONField"
+	FieldTypeNumber   = "sq.NumberField"
+	FieldTypeString   = "sq.StringField"
+	FieldTypeTime     = "sq.TimeField"
+	FieldTypeEnum     = "sq.EnumField"
+	FieldTypeArray    = "sq.ArrayField"
+	FieldTypeBinary   = "sq.BinaryField"
+	FieldTypeTSVector = "sq.TSVectorField"
+	FieldTypeTSQuery  = "sq.TSQueryField"
+	FieldTypeRange    = "sq.RangeField"
+	FieldTypeUUID     = "sq.UUIDField"
+
+	FieldConstructorBoolean  = "sq.NewBooleanField"
+	FieldConstructorJSON     = "sq.NewJSONField"
+	FieldConstructorNumber   = "sq.NewNumberField"
+	FieldConstructorString   = "sq.NewStringField"
+	FieldConstructorTime     = "sq.NewTimeField"
+	FieldConstructorEnum     = "sq.NewEnumField"
+	FieldConstructorArray    = "sq.NewArrayField"
+	FieldConstructorBinary   = "sq.NewBinaryField"
+	FieldConstructorTSVector = "sq.NewTSVectorField"
+	FieldConstructorTSQuery  = "sq.NewTSQueryField"
+	FieldConstructorRange    = "sq.NewRangeField"
+	FieldConstructorUUID     = "sq.NewUUIDField"
 )
 
 var tablesCmd = &cobra.Command{
@@ -389,6 +397,34 @@ func (field TableField) fillInTheBlanks() TableField {
 		return field
 	}
 
+	// UUID
+	if field.RawType == "uuid" {
+		field.Type = FieldTypeUUID
+		field.Constructor = FieldConstructorUUID
+		return field
+	}
+
+	// Full text search
+	switch field.RawType {
+	case "tsvector":
+		field.Type = FieldTypeTSVector
+		field.Constructor = FieldConstructorTSVector
+		return field
+	case "tsquery":
+		field.Type = FieldTypeTSQuery
+		field.Constructor = FieldConstructorTSQuery
+		return field
+	}
+
+	// Range and multirange
+	switch field.RawType {
+	case "int4range", "int8range", "numrange", "tsrange", "tstzrange", "daterange",
+		"int4multirange", "int8multirange", "nummultirange", "tsmultirange", "tstzmultirange", "datemultirange":
+		field.Type = FieldTypeRange
+		field.Constructor = FieldConstructorRange
+		return field
+	}
+
 	return field
 }
 
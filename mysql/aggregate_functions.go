@@ -1,5 +1,10 @@
 package sq
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Count represents the COUNT(*) aggregate function.
 func Count() NumberField {
 	format := "COUNT(*)"
@@ -88,3 +93,183 @@ func MaxOver(field interface{}, window Window) NumberField {
 		values: []interface{}{field, window},
 	}
 }
+
+// CountDistinct represents the COUNT(DISTINCT) aggregate function.
+func CountDistinct(field interface{}) NumberField {
+	format := "COUNT(DISTINCT ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field},
+	}
+}
+
+// GroupConcat represents the GROUP_CONCAT() aggregate function, which
+// concatenates the values separated by the separator. If orderBy fields are
+// provided, the values are concatenated in that order i.e. 'GROUP_CONCAT(X
+// ORDER BY Y SEPARATOR sep)'. MySQL does not accept a placeholder for the
+// separator, so it is written into the query as an escaped string literal.
+func GroupConcat(field interface{}, separator string, orderBy ...Field) StringField {
+	separator = strings.NewReplacer(`\`, `\\`, "'", "''").Replace(separator)
+	format := "GROUP_CONCAT(? SEPARATOR '" + separator + "')"
+	values := []interface{}{field}
+	if len(orderBy) > 0 {
+		format = "GROUP_CONCAT(? ORDER BY ? SEPARATOR '" + separator + "')"
+		values = append(values, Fields(orderBy))
+	}
+	return StringField{
+		format: &format,
+		values: values,
+	}
+}
+
+// BoolAnd returns a BooleanField that is true if the predicate is true for
+// every row. MySQL has no BOOL_AND(), so it is emulated with MIN().
+func BoolAnd(predicate Predicate) BooleanField {
+	format := "MIN(?)"
+	return BooleanField{
+		format: &format,
+		values: []interface{}{predicate},
+	}
+}
+
+// BoolOr returns a BooleanField that is true if the predicate is true for any
+// row. MySQL has no BOOL_OR(), so it is emulated with MAX().
+func BoolOr(predicate Predicate) BooleanField {
+	format := "MAX(?)"
+	return BooleanField{
+		format: &format,
+		values: []interface{}{predicate},
+	}
+}
+
+// Stddev represents the STDDEV_SAMP() aggregate function, which returns the
+// sample standard deviation. Note that MySQL's STDDEV() is the population
+// standard deviation.
+func Stddev(field interface{}) NumberField {
+	format := "STDDEV_SAMP(?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field},
+	}
+}
+
+// Variance represents the VAR_SAMP() aggregate function, which returns the
+// sample variance. Note that MySQL's VARIANCE() is the population variance.
+func Variance(field interface{}) NumberField {
+	format := "VAR_SAMP(?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field},
+	}
+}
+
+// BoolAndOver represents the MIN() OVER window function, emulating
+// BOOL_AND() OVER.
+func BoolAndOver(predicate Predicate, window Window) BooleanField {
+	format := "MIN(?) OVER ?"
+	return BooleanField{
+		format: &format,
+		values: []interface{}{predicate, window},
+	}
+}
+
+// BoolOrOver represents the MAX() OVER window function, emulating BOOL_OR()
+// OVER.
+func BoolOrOver(predicate Predicate, window Window) BooleanField {
+	format := "MAX(?) OVER ?"
+	return BooleanField{
+		format: &format,
+		values: []interface{}{predicate, window},
+	}
+}
+
+// StddevOver represents the STDDEV_SAMP() OVER window function.
+func StddevOver(field interface{}, window Window) NumberField {
+	format := "STDDEV_SAMP(?) OVER ?"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field, window},
+	}
+}
+
+// VarianceOver represents the VAR_SAMP() OVER window function.
+func VarianceOver(field interface{}, window Window) NumberField {
+	format := "VAR_SAMP(?) OVER ?"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field, window},
+	}
+}
+
+// filterAggregates are the aggregate functions that filterAggregate can
+// filter, all of which ignore NULLs.
+var filterAggregates = map[string]bool{
+	"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true,
+	"GROUP_CONCAT": true, "JSON_ARRAYAGG": true,
+	"BIT_AND": true, "BIT_OR": true, "BIT_XOR": true,
+	"STD": true, "STDDEV": true, "STDDEV_POP": true, "STDDEV_SAMP": true,
+	"VARIANCE": true, "VAR_POP": true, "VAR_SAMP": true,
+}
+
+// filterAggregate emulates a 'FILTER (WHERE predicates)' clause on an
+// aggregate function's format and values, which MySQL does not support, by
+// wrapping the aggregated value in 'CASE WHEN predicates THEN X END'. Rows
+// that fail the predicates become NULL, which aggregate functions ignore. It
+// panics if the field is not one of the filterAggregates.
+func filterAggregate(format *string, values []interface{}, predicates []Predicate) (string, []interface{}) {
+	if format == nil {
+		panic(fmt.Errorf("sq: FILTER can only be applied to an aggregate function"))
+	}
+	if i := strings.Index(*format, "("); i < 0 || !filterAggregates[strings.ToUpper((*format)[:i])] {
+		panic(fmt.Errorf("sq: FILTER cannot be applied to %s, only to an aggregate function", *format))
+	}
+	filter := VariadicPredicate{
+		toplevel:   true,
+		Operator:   PredicateAnd,
+		Predicates: predicates,
+	}
+	if strings.HasPrefix(*format, "COUNT(*)") {
+		// COUNT(CASE WHEN ? THEN 1 END)
+		newFormat := "COUNT(CASE WHEN ? THEN 1 END)" + strings.TrimPrefix(*format, "COUNT(*)")
+		return newFormat, append([]interface{}{filter}, values...)
+	}
+	i := strings.Index(*format, "?")
+	if i < 0 || len(values) == 0 || !(strings.HasSuffix((*format)[:i], "(") || strings.HasSuffix((*format)[:i], "(DISTINCT ")) {
+		panic(fmt.Errorf("sq: FILTER can only be applied to an aggregate function"))
+	}
+	// SUM(CASE WHEN ? THEN ? END)
+	newValues := make([]interface{}, len(values))
+	copy(newValues, values)
+	newValues[0] = CustomField{
+		Format: "CASE WHEN ? THEN ? END",
+		Values: []interface{}{filter, values[0]},
+	}
+	return *format, newValues
+}
+
+// Filter returns a new NumberField that only aggregates the rows matching the
+// predicates, like Postgres' 'FILTER (WHERE predicates)'. It panics if the
+// NumberField is not an aggregate function.
+func (f NumberField) Filter(predicates ...Predicate) NumberField {
+	format, values := filterAggregate(f.format, f.values, predicates)
+	f.format, f.values = &format, values
+	return f
+}
+
+// Filter returns a new StringField that only aggregates the rows matching the
+// predicates, like Postgres' 'FILTER (WHERE predicates)'. It panics if the
+// StringField is not an aggregate function.
+func (f StringField) Filter(predicates ...Predicate) StringField {
+	format, values := filterAggregate(f.format, f.values, predicates)
+	f.format, f.values = &format, values
+	return f
+}
+
+// Filter returns a new BooleanField that only aggregates the rows matching the
+// predicates, like Postgres' 'FILTER (WHERE predicates)'. It panics if the
+// BooleanField is not an aggregate function.
+func (f BooleanField) Filter(predicates ...Predicate) BooleanField {
+	format, values := filterAggregate(f.format, f.values, predicates)
+	f.format, f.values = &format, values
+	return f
+}
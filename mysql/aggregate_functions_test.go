@@ -87,6 +87,104 @@ func TestAggregateFunctions(t *testing.T) {
 			"MAX(ur.user_role_id) OVER (PARTITION BY ur.user_id)",
 			nil,
 		},
+		{
+			"CountDistinct",
+			CountDistinct(ur.ROLE),
+			nil,
+			"COUNT(DISTINCT ur.role)",
+			nil,
+		},
+		{
+			"GroupConcat",
+			GroupConcat(ur.ROLE, ", "),
+			nil,
+			"GROUP_CONCAT(ur.role SEPARATOR ', ')",
+			nil,
+		},
+		{
+			"GroupConcat ORDER BY",
+			GroupConcat(ur.ROLE, `it's \`, ur.CREATED_AT.Desc(), ur.ROLE),
+			nil,
+			`GROUP_CONCAT(ur.role ORDER BY ur.created_at DESC, ur.role SEPARATOR 'it''s \\')`,
+			nil,
+		},
+		{
+			"BoolAnd",
+			BoolAnd(ur.DELETED_AT.IsNull()),
+			nil,
+			"MIN(ur.deleted_at IS NULL)",
+			nil,
+		},
+		{
+			"BoolOr",
+			BoolOr(ur.ROLE.EqString("admin")),
+			nil,
+			"MAX(ur.role = ?)",
+			[]interface{}{"admin"},
+		},
+		{
+			"Stddev",
+			Stddev(ur.USER_ID),
+			nil,
+			"STDDEV_SAMP(ur.user_id)",
+			nil,
+		},
+		{
+			"Variance",
+			Variance(ur.USER_ID),
+			nil,
+			"VAR_SAMP(ur.user_id)",
+			nil,
+		},
+		{
+			"Count Filter",
+			Count().Filter(ur.ROLE.EqString("admin"), ur.DELETED_AT.IsNull()),
+			nil,
+			"COUNT(CASE WHEN ur.role = ? AND ur.deleted_at IS NULL THEN 1 END)",
+			[]interface{}{"admin"},
+		},
+		{
+			"CountOver Filter",
+			CountOver(PartitionBy(ur.ROLE)).Filter(ur.DELETED_AT.IsNull()),
+			nil,
+			"COUNT(CASE WHEN ur.deleted_at IS NULL THEN 1 END) OVER (PARTITION BY ur.role)",
+			nil,
+		},
+		{
+			"Sum Filter",
+			Sum(ur.USER_ID).Filter(ur.DELETED_AT.IsNull()),
+			nil,
+			"SUM(CASE WHEN ur.deleted_at IS NULL THEN ur.user_id END)",
+			nil,
+		},
+		{
+			"CountDistinct Filter",
+			CountDistinct(ur.ROLE).Filter(ur.USER_ID.GtInt(1)),
+			nil,
+			"COUNT(DISTINCT CASE WHEN ur.user_id > ? THEN ur.role END)",
+			[]interface{}{1},
+		},
+		{
+			"GroupConcat Filter",
+			GroupConcat(ur.ROLE, ",", ur.ROLE).Filter(ur.USER_ID.GtInt(1)),
+			nil,
+			"GROUP_CONCAT(CASE WHEN ur.user_id > ? THEN ur.role END ORDER BY ur.role SEPARATOR ',')",
+			[]interface{}{1},
+		},
+		{
+			"custom aggregate Filter",
+			NumberFieldf("bit_or(?)", ur.USER_ID).Filter(ur.DELETED_AT.IsNull()),
+			nil,
+			"bit_or(CASE WHEN ur.deleted_at IS NULL THEN ur.user_id END)",
+			nil,
+		},
+		{
+			"BoolOr Filter",
+			BoolOr(ur.DELETED_AT.IsNull()).Filter(ur.USER_ID.GtInt(1)),
+			nil,
+			"MAX(CASE WHEN ur.user_id > ? THEN ur.deleted_at IS NULL END)",
+			[]interface{}{1},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -101,3 +199,26 @@ func TestAggregateFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateFunctions_FilterPanics(t *testing.T) {
+	type TT struct {
+		description string
+		filter      func()
+	}
+	ur := USER_ROLES().As("ur")
+	tests := []TT{
+		{"column", func() { ur.USER_ID.Filter(ur.DELETED_AT.IsNull()) }},
+		{"scalar function", func() { NumberFieldf("ABS(?)", ur.USER_ID).Filter(ur.DELETED_AT.IsNull()) }},
+		{"string function", func() { StringFieldf("LOWER(?)", ur.ROLE).Filter(ur.DELETED_AT.IsNull()) }},
+		{"no argument", func() { NumberFieldf("SUM(1)").Filter(ur.DELETED_AT.IsNull()) }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			defer func() { is.True(recover() != nil) }()
+			tt.filter()
+		})
+	}
+}
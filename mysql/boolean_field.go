@@ -2,18 +2,28 @@ package sq
 
 import "strings"
 
-// BooleanField either represents a boolean column or a literal bool value.
+// BooleanField either represents a boolean column, a boolean expression or a
+// literal bool value.
 type BooleanField struct {
 	// BooleanField will be one of the following:
 
-	// 1) Literal bool value
+	// 1) Boolean expression
+	// Examples of boolean expressions:
+	// | query                | args |
+	// |----------------------|------|
+	// | users.score > ?      | 5    |
+	// | COALESCE(users.x, ?) | true |
+	format *string
+	values []interface{}
+
+	// 2) Literal bool value
 	// Examples of literal bool values:
 	// | query | args |
 	// |-------|------|
 	// | ?     | true |
 	value *bool
 
-	// 2) Boolean column
+	// 3) Boolean column
 	// Examples of boolean columns:
 	// | query            | args |
 	// |------------------|------|
@@ -34,12 +44,15 @@ func (f BooleanField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}
 		buf.WriteString("NOT ")
 	}
 	switch {
+	case f.format != nil:
+		// 1) Boolean expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal bool value
+		// 2) Literal bool value
 		buf.WriteString("?")
 		*args = append(*args, *f.value)
 	default:
-		// 2) Boolean column
+		// 3) Boolean column
 		tableQualifier := f.table.GetAlias()
 		if tableQualifier == "" {
 			tableQualifier = f.table.GetName()
@@ -187,3 +200,11 @@ func (f BooleanField) Not() Predicate {
 	f.negative = !f.negative
 	return f
 }
+
+// BooleanFieldf creates a new boolean expression.
+func BooleanFieldf(format string, values ...interface{}) BooleanField {
+	return BooleanField{
+		format: &format,
+		values: values,
+	}
+}
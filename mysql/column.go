@@ -29,6 +29,9 @@ func (col *Column) Set(field Field, value interface{}) {
 		// should I panic with an error here instead?
 		return
 	}
+	if _, ok := field.(UUIDField); ok {
+		value = uuidValue(value)
+	}
 	switch col.mode {
 	case colmodeUpdate:
 		col.assignments = append(col.assignments, FieldAssignment{
@@ -91,3 +94,8 @@ func (col *Column) SetString(field StringField, value string) {
 func (col *Column) SetTime(field TimeField, value time.Time) {
 	col.Set(field, value)
 }
+
+// SetUUID maps the uuid value to the UUIDField.
+func (col *Column) SetUUID(field UUIDField, value [16]byte) {
+	col.Set(field, value)
+}
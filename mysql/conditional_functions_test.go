@@ -0,0 +1,74 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestConditionalFunctions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u, s := USERS().As("u"), SESSIONS().As("s")
+	tests := []TT{
+		{"CoalesceNumber", CoalesceNumber(u.USER_ID, 0), nil, "COALESCE(u.user_id, ?)", []interface{}{0}},
+		{"CoalesceString", CoalesceString(u.DISPLAYNAME, u.EMAIL, ""), []string{"u"}, "COALESCE(displayname, email, ?)", []interface{}{""}},
+		{"CoalesceTime", CoalesceTime(s.CREATED_AT, Now()), nil, "COALESCE(s.created_at, NOW())", nil},
+		{"CoalesceBoolean", CoalesceBoolean(BooleanFieldf("? > ?", u.USER_ID, 5), false), nil, "COALESCE(u.user_id > ?, ?)", []interface{}{5, false}},
+		{"NullIf number", u.USER_ID.NullIf(0), nil, "NULLIF(u.user_id, ?)", []interface{}{0}},
+		{"NullIf string", u.DISPLAYNAME.NullIf(""), nil, "NULLIF(u.displayname, ?)", []interface{}{""}},
+		{"NullIf time", s.CREATED_AT.NullIf(Now()), nil, "NULLIF(s.created_at, NOW())", nil},
+		{"Greatest number", u.USER_ID.Greatest(1, u.USER_ID.Neg()), nil, "GREATEST(u.user_id, ?, (-u.user_id))", []interface{}{1}},
+		{"Least number", u.USER_ID.Least(10), nil, "LEAST(u.user_id, ?)", []interface{}{10}},
+		{"Greatest string", u.DISPLAYNAME.Greatest(u.EMAIL), nil, "GREATEST(u.displayname, u.email)", nil},
+		{"Least time", s.CREATED_AT.Least(Now()), nil, "LEAST(s.created_at, NOW())", nil},
+		{"Cast AsNumber", Cast(u.DISPLAYNAME, "DECIMAL(10, 2)").AsNumber(), nil, "CAST(u.displayname AS DECIMAL(10, 2))", nil},
+		{"Cast AsString", Cast(u.USER_ID, "CHAR").AsString(), nil, "CAST(u.user_id AS CHAR)", nil},
+		{"Cast AsTime", Cast("2020-01-01", "DATETIME").AsTime(), nil, "CAST(? AS DATETIME)", []interface{}{"2020-01-01"}},
+		{"StringFieldf", StringFieldf("REVERSE(?)", u.DISPLAYNAME), nil, "REVERSE(u.displayname)", nil},
+		{"TimeFieldf", TimeFieldf("FROM_UNIXTIME(?)", 0), nil, "FROM_UNIXTIME(?)", []interface{}{0}},
+		{"BooleanFieldf Not", BooleanFieldf("? IS NULL", u.EMAIL).Not(), nil, "NOT u.email IS NULL", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestConditionalFunctions_RowMapper(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	r := &Row{}
+	_ = r.Int(CoalesceNumber(u.USER_ID, 0))
+	_ = r.String(CoalesceString(u.DISPLAYNAME.NullIf(""), u.EMAIL))
+	gotQuery, gotArgs := From(u).Select(r.fields...).ToSQL()
+	is.Equal("SELECT COALESCE(u.user_id, ?), COALESCE(NULLIF(u.displayname, ?), u.email) FROM devlab.users AS u", gotQuery)
+	is.Equal([]interface{}{0, ""}, gotArgs)
+}
+
+func TestCast_Panics(t *testing.T) {
+	is := is.New(t)
+	for _, typename := range []string{"SIGNED", "CHAR", "CHAR(255)", "DECIMAL(10, 2)", "DECIMAL(10,2)", "unsigned integer", "DOUBLE PRECISION"} {
+		is.True(validCastType(typename)) // typename should be valid
+	}
+	for _, typename := range []string{"int); DROP TABLE users; --", "SIGNED) , (SELECT secret FROM t", "SIGNED, CHAR", "DECIMAL(10, 2, 3)", "DECIMAL()", "DECIMAL(a)", "DECIMAL (10)", "8CHAR", "JSON[]", "SIGNED  INTEGER"} {
+		func() {
+			defer func() { is.True(recover() != nil) }() // Cast should panic
+			Cast(1, typename)
+		}()
+	}
+}
@@ -1,6 +1,7 @@
 package sq
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -16,7 +17,7 @@ const (
 // CTE represents an SQL CTE.
 type CTE map[string]CustomField
 
-func appendCTEs(buf *strings.Builder, args *[]interface{}, CTEs []CTE, fromTable Table, joinTables []JoinTable) {
+func appendCTEs(buf *strings.Builder, args *[]interface{}, CTEs []CTE, fromTable Table, joinTables []JoinTable, format sqlFormat) {
 	type TmpCTE struct {
 		name    string
 		columns []string
@@ -75,13 +76,13 @@ func appendCTEs(buf *strings.Builder, args *[]interface{}, CTEs []CTE, fromTable
 			buf.WriteString("NULL")
 		case VariadicQuery:
 			q.topLevel = true
-			q.NestThis().AppendSQL(buf, args, nil)
+			format.appendQuery(buf, args, q)
 		default:
-			q.NestThis().AppendSQL(buf, args, nil)
+			format.appendQuery(buf, args, q)
 		}
 		buf.WriteString(")")
 	}
-	buf.WriteString(" ")
+	format.newline(buf)
 }
 
 // CTE converts a SelectQuery into a CTE.
@@ -92,6 +93,12 @@ func (q SelectQuery) CTE(name string, columns ...string) CTE {
 		metadataAlias:   {Values: []interface{}{""}},
 		metadataColumns: {Values: []interface{}{columns}},
 	}
+	if len(columns) > 0 {
+		for _, column := range columns {
+			cte[column] = CustomField{Format: name + "." + column}
+		}
+		return cte
+	}
 	for _, field := range q.SelectFields {
 		column := getAliasOrName(field)
 		cte[column] = CustomField{Format: name + "." + column}
@@ -203,6 +210,62 @@ func (cte CTE) GetAlias() string {
 	return ""
 }
 
+// column returns the name of the CTE column. It panics if the CTE does not
+// have that column, which is either one of the columns passed to CTE() or one
+// of the fields selected by the CTE's query.
+func (cte CTE) column(name string) string {
+	switch name {
+	case metadataQuery, metadataRecursive, metadataName, metadataAlias, metadataColumns:
+	default:
+		if _, ok := cte[name]; ok {
+			return name
+		}
+	}
+	panic(fmt.Errorf("sq: CTE %s has no column %s", cte.GetName(), name))
+}
+
+// NumberField returns the CTE column as a NumberField. It panics if the CTE does
+// not have that column.
+func (cte CTE) NumberField(column string) NumberField {
+	return NewNumberField(cte.column(column), cte)
+}
+
+// StringField returns the CTE column as a StringField. It panics if the CTE does
+// not have that column.
+func (cte CTE) StringField(column string) StringField {
+	return NewStringField(cte.column(column), cte)
+}
+
+// BooleanField returns the CTE column as a BooleanField. It panics if the CTE does
+// not have that column.
+func (cte CTE) BooleanField(column string) BooleanField {
+	return NewBooleanField(cte.column(column), cte)
+}
+
+// TimeField returns the CTE column as a TimeField. It panics if the CTE does
+// not have that column.
+func (cte CTE) TimeField(column string) TimeField {
+	return NewTimeField(cte.column(column), cte)
+}
+
+// JSONField returns the CTE column as a JSONField. It panics if the CTE does
+// not have that column.
+func (cte CTE) JSONField(column string) JSONField {
+	return NewJSONField(cte.column(column), cte)
+}
+
+// BinaryField returns the CTE column as a BinaryField. It panics if the CTE does
+// not have that column.
+func (cte CTE) BinaryField(column string) BinaryField {
+	return NewBinaryField(cte.column(column), cte)
+}
+
+// UUIDField returns the CTE column as a UUIDField. It panics if the CTE does
+// not have that column.
+func (cte CTE) UUIDField(column string) UUIDField {
+	return NewUUIDField(cte.column(column), cte)
+}
+
 // RecursiveCTE constructs a new recursive CTE.
 func RecursiveCTE(name string, columns ...string) CTE {
 	cte := map[string]CustomField{
@@ -131,6 +131,54 @@ func TestCTEs_AppendSQL(t *testing.T) {
 			tt.wantArgs = []interface{}{1, 2, 3}
 			return tt
 		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Select CTE typed columns"
+			u := USERS().As("u")
+			cte := Select(u.USER_ID, u.DISPLAYNAME, Count().As("count")).
+				From(u).
+				GroupBy(u.USER_ID, u.DISPLAYNAME).
+				CTE("cte", "id", "name", "total")
+			id, name, total := cte.NumberField("id"), cte.StringField("name"), cte.NumberField("total")
+			tt.q = Select(id, name, total).From(cte).Where(total.GtInt(1), name.LikeString("a%")).OrderBy(id.Desc())
+			tt.wantQuery = "WITH cte (id, name, total) AS" +
+				" (SELECT u.user_id, u.displayname, COUNT(*) AS count FROM devlab.users AS u GROUP BY u.user_id, u.displayname)" +
+				" SELECT cte.id, cte.name, cte.total FROM cte WHERE cte.total > ? AND cte.name LIKE ? ORDER BY cte.id DESC"
+			tt.wantArgs = []interface{}{1, "a%"}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Select CTE aliased typed columns"
+			u := USERS().As("u")
+			cte := Select(u.USER_ID, u.EMAIL).From(u).CTE("cte")
+			c := cte.As("c")
+			tt.q = Select(c.NumberField("user_id")).From(c).Where(c.StringField("email").EqString("bob@email.com"))
+			tt.wantQuery = "WITH cte AS" +
+				" (SELECT u.user_id, u.email FROM devlab.users AS u)" +
+				" SELECT c.user_id FROM cte AS c WHERE c.email = ?"
+			tt.wantArgs = []interface{}{"bob@email.com"}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Recursive CTE typed columns"
+			tens := RecursiveCTE("tens", "n")
+			n := tens.NumberField("n")
+			tens = tens.
+				Initial(Select(Int(10))).
+				UnionAll(
+					Select(n.Add(10)).From(tens).Where(n.Add(10).LeInt(100)),
+				)
+			tt.q = Select(n).From(tens)
+			tt.wantQuery = "WITH RECURSIVE tens (n) AS" +
+				" (SELECT ?" +
+				" UNION ALL" +
+				" SELECT (tens.n + ?) FROM tens WHERE (tens.n + ?) <= ?)" +
+				" SELECT tens.n FROM tens"
+			tt.wantArgs = []interface{}{10, 10, 10, 100}
+			return tt
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -143,3 +191,17 @@ func TestCTEs_AppendSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestCTE_TypedColumnPanics(t *testing.T) {
+	u := USERS().As("u")
+	cte := Select(u.USER_ID, u.EMAIL).From(u).CTE("cte", "id", "email")
+	tests := []string{"user_id", "idd", metadataQuery}
+	for _, column := range tests {
+		column := column
+		t.Run(column, func(t *testing.T) {
+			is := is.New(t)
+			defer func() { is.True(recover() != nil) }()
+			cte.NumberField(column)
+		})
+	}
+}
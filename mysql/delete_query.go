@@ -14,6 +14,7 @@ import (
 // DeleteQuery represents a DELETE query.
 type DeleteQuery struct {
 	nested bool
+	format sqlFormat
 	Alias  string
 	// WITH
 	CTEs []CTE
@@ -30,6 +31,9 @@ type DeleteQuery struct {
 	LimitValue *int64
 	// DB
 	DB DB
+	// AutoValidate makes Fetch and Exec call Validate before running the
+	// query.
+	AutoValidate bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -49,7 +53,7 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
 	// WITH
 	if !q.nested {
-		appendCTEs(buf, args, q.CTEs, nil, q.JoinTables)
+		appendCTEs(buf, args, q.CTEs, nil, q.JoinTables, q.format)
 	}
 	// DELETE FROM
 	buf.WriteString("DELETE FROM ")
@@ -74,11 +78,12 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// USING
 	if q.UsingTable != nil {
-		buf.WriteString(" USING ")
+		q.format.newline(buf)
+		buf.WriteString("USING ")
 		switch v := q.UsingTable.(type) {
 		case Query:
 			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args, nil)
+			q.format.appendQuery(buf, args, v)
 			buf.WriteString(")")
 		default:
 			q.UsingTable.AppendSQL(buf, args, nil)
@@ -91,23 +96,25 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
-		buf.WriteString(" ")
-		q.JoinTables.AppendSQL(buf, args, nil)
+		q.format.appendJoinTables(buf, args, q.JoinTables)
 	}
 	// WHERE
 	if len(q.WherePredicate.Predicates) > 0 {
-		buf.WriteString(" WHERE ")
+		q.format.newline(buf)
+		buf.WriteString("WHERE ")
 		q.WherePredicate.toplevel = true
-		q.WherePredicate.AppendSQLExclude(buf, args, nil, nil)
+		q.format.appendPredicate(buf, args, q.WherePredicate, nil)
 	}
 	// ORDER BY
 	if len(q.OrderByFields) > 0 {
-		buf.WriteString(" ORDER BY ")
+		q.format.newline(buf)
+		buf.WriteString("ORDER BY ")
 		q.OrderByFields.AppendSQLExclude(buf, args, nil, nil)
 	}
 	// LIMIT
 	if q.LimitValue != nil {
-		buf.WriteString(" LIMIT ?")
+		q.format.newline(buf)
+		buf.WriteString("LIMIT ?")
 		if *q.LimitValue < 0 {
 			*q.LimitValue = -*q.LimitValue
 		}
@@ -115,16 +122,19 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	if !q.nested {
 		if q.Log != nil {
-			query := buf.String()
+			query, logArgs := buf.String(), *args
+			if Lpretty&q.LogFlag != 0 && !q.format.pretty {
+				query, logArgs = prettyLogQuery(q)
+			}
 			var logOutput string
 			switch {
 			case Lstats&q.LogFlag != 0:
-				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(*args) +
-					"\n----[ with bind values ]----\n" + questionInterpolate(query, *args...)
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(logArgs) +
+					"\n----[ with bind values ]----\n" + questionInterpolate(query, logArgs...)
 			case Linterpolate&q.LogFlag != 0:
-				logOutput = "Executing query: " + questionInterpolate(query, *args...)
+				logOutput = "Executing query: " + questionInterpolate(query, logArgs...)
 			default:
-				logOutput = "Executing query: " + query + " " + fmt.Sprint(*args)
+				logOutput = "Executing query: " + query + " " + fmt.Sprint(logArgs)
 			}
 			switch q.Log.(type) {
 			case *log.Logger:
@@ -268,6 +278,11 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 		db = q.DB
 	}
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return rowsAffected, err
+		}
+	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
 	defer func() {
@@ -275,6 +290,7 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, rowsAffected, elapsed, err)
 		if Lstats&q.LogFlag != 0 && ErowsAffected&flag != 0 {
 			logBuf.WriteString("\n(Deleted ")
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
@@ -296,6 +312,7 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
@@ -0,0 +1,194 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestFingerprint(t *testing.T) {
+	type TT struct {
+		description string
+		q           Query
+		wantQuery   string
+	}
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	tests := []TT{
+		{
+			"nil query",
+			nil,
+			"",
+		},
+		{
+			"in list",
+			From(u).Where(u.USER_ID.In([]int{1, 2, 3}), u.EMAIL.EqString("bob@email.com")).Select(u.USER_ID),
+			"SELECT u.user_id FROM devlab.users AS u WHERE u.user_id IN (?) AND u.email = ?",
+		},
+		{
+			"not in list",
+			From(u).Where(u.USER_ID.NotIn([]int{1, 2})).Select(u.USER_ID),
+			"SELECT u.user_id FROM devlab.users AS u WHERE u.user_id NOT IN (?)",
+		},
+		{
+			"row values",
+			From(u).Where(RowValue{u.USER_ID, u.EMAIL}.In(RowValues{{1, "a"}, {2, "b"}})).Select(u.USER_ID),
+			"SELECT u.user_id FROM devlab.users AS u WHERE (u.user_id, u.email) IN ((?))",
+		},
+		{
+			"join and having",
+			From(u).
+				Join(ur, ur.USER_ID.Eq(u.USER_ID), ur.ROLE.In([]string{"admin", "staff"})).
+				GroupBy(u.USER_ID).
+				Having(Count().In([]int{1, 2})).
+				Select(u.USER_ID),
+			"SELECT u.user_id FROM devlab.users AS u JOIN devlab.user_roles AS ur ON ur.user_id = u.user_id AND ur.role IN (?) GROUP BY u.user_id HAVING COUNT(*) IN (?)",
+		},
+		{
+			"subquery",
+			DeleteFrom(u).Where(u.USER_ID.In(From(ur).Where(ur.ROLE.In([]string{"a", "b"})).Select(ur.USER_ID))),
+			"DELETE FROM u WHERE u.user_id IN (SELECT ur.user_id FROM devlab.user_roles AS ur WHERE ur.role IN (?))",
+		},
+		{
+			"update",
+			Update(u).Set(u.DISPLAYNAME.SetString("bob")).Where(u.USER_ID.In([]int{1, 2})),
+			"UPDATE devlab.users AS u SET u.displayname = ? WHERE u.user_id IN (?)",
+		},
+		{
+			"union",
+			Union(
+				From(u).Where(u.USER_ID.In([]int{1, 2})).Select(u.USER_ID),
+				From(u).Where(u.USER_ID.In([]int{3})).Select(u.USER_ID),
+			),
+			"SELECT u.user_id FROM devlab.users AS u WHERE u.user_id IN (?) UNION SELECT u.user_id FROM devlab.users AS u WHERE u.user_id IN (?)",
+		},
+		{
+			"select list and order by",
+			From(u).
+				Where(u.USER_ID.In([]int{1, 2})).
+				OrderBy(Fieldf("? IN (?)", u.DISPLAYNAME, []string{"a", "b"}).Desc()).
+				Select(Fieldf("CASE WHEN ? IN (?) THEN 1 END", u.USER_ID, []int{1, 2, 3})),
+			"SELECT CASE WHEN u.user_id IN (?) THEN 1 END FROM devlab.users AS u WHERE u.user_id IN (?) ORDER BY u.displayname IN (?) DESC",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, _ := Fingerprint(tt.q)
+			is.Equal(tt.wantQuery, gotQuery)
+		})
+	}
+}
+
+func TestFingerprint_Hash(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	query := func(ids interface{}, email string) SelectQuery {
+		return From(u).Where(u.USER_ID.In(ids), u.EMAIL.EqString(email)).Select(u.USER_ID)
+	}
+	_, hash1 := Fingerprint(query([]int{1, 2, 3}, "bob@email.com"))
+	_, hash2 := Fingerprint(query([]int{4}, "alice@email.com"))
+	is.Equal(hash1, hash2)
+	_, hash3 := Fingerprint(From(u).Where(u.USER_ID.In([]int{1, 2, 3})).Select(u.USER_ID))
+	is.True(hash1 != hash3)
+	// Fingerprint does not change what ToSQL renders
+	gotQuery, gotArgs := query([]int{1, 2, 3}, "bob@email.com").ToSQL()
+	is.Equal("SELECT u.user_id FROM devlab.users AS u WHERE u.user_id IN (?, ?, ?) AND u.email = ?", gotQuery)
+	is.Equal([]interface{}{1, 2, 3, "bob@email.com"}, gotArgs)
+}
+
+func TestFingerprint_UUID(t *testing.T) {
+	is := is.New(t)
+	m := MEDIA().As("m")
+	id1 := [16]byte{1}
+	id2 := [16]byte{2}
+	id3 := [16]byte{3}
+	query1, hash1 := Fingerprint(From(m).Where(m.UUID.In([][16]byte{id1, id2})).Select(m.UUID))
+	query2, hash2 := Fingerprint(From(m).Where(m.UUID.In([][16]byte{id1, id2, id3})).Select(m.UUID))
+	is.Equal("SELECT m.uuid FROM devlab.media AS m WHERE m.uuid IN (?)", query1)
+	is.Equal(query1, query2)
+	is.Equal(hash1, hash2)
+	// the IN list is still expanded by ToSQL
+	gotQuery, gotArgs := From(m).Where(m.UUID.In([][16]byte{id1, id2})).Select(m.UUID).ToSQL()
+	is.Equal("SELECT m.uuid FROM devlab.media AS m WHERE m.uuid IN (?, ?)", gotQuery)
+	is.Equal(2, len(gotArgs))
+	query3, _ := Fingerprint(From(m).Where(m.UUID.NotIn([][16]byte{id1, id2, id3})).Select(m.UUID))
+	is.Equal("SELECT m.uuid FROM devlab.media AS m WHERE m.uuid NOT IN (?)", query3)
+}
+
+type statsTestLogger struct {
+	prettyTestLogger
+	stats []QueryStats
+}
+
+func (l *statsTestLogger) LogQueryStats(stats QueryStats) {
+	l.stats = append(l.stats, stats)
+}
+
+type statsTestDB struct {
+	DB
+	err error
+}
+
+func (db statsTestDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	return driver.RowsAffected(3), nil
+}
+
+func TestStatsLogger(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	logger := &statsTestLogger{}
+	q := Update(u).Set(u.DISPLAYNAME.SetString("bob")).Where(u.USER_ID.In([]int{1, 2, 3}))
+	q.Log = logger
+	_, err := q.Exec(statsTestDB{}, ErowsAffected)
+	is.NoErr(err)
+	wantQuery, wantHash := Fingerprint(q)
+	is.Equal(1, len(logger.stats))
+	is.Equal(wantQuery, logger.stats[0].Query)
+	is.Equal("UPDATE devlab.users AS u SET u.displayname = ? WHERE u.user_id IN (?)", logger.stats[0].Query)
+	is.Equal(wantHash, logger.stats[0].Fingerprint)
+	is.Equal(int64(3), logger.stats[0].RowCount)
+	is.NoErr(logger.stats[0].Err)
+
+	errExec := errors.New("exec failed")
+	_, err = q.Exec(statsTestDB{err: errExec}, 0)
+	is.Equal(errExec, err)
+	is.Equal(2, len(logger.stats))
+	is.Equal(errExec, logger.stats[1].Err)
+}
+
+type fingerprintTestDB struct {
+	statsTestDB
+	fingerprints *[]string
+}
+
+func (db fingerprintTestDB) WantsFingerprint() bool { return true }
+
+func (db fingerprintTestDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	fingerprint, _ := ContextFingerprint(ctx)
+	*db.fingerprints = append(*db.fingerprints, fingerprint)
+	return driver.RowsAffected(0), nil
+}
+
+func TestFingerprintDB(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	q := DeleteFrom(u).Where(u.USER_ID.In([]int{1, 2, 3}))
+	var fingerprints []string
+	_, err := q.Exec(fingerprintTestDB{fingerprints: &fingerprints}, 0)
+	is.NoErr(err)
+	wantQuery, _ := Fingerprint(q)
+	is.Equal([]string{wantQuery}, fingerprints)
+
+	// the fields of a SelectQuery are taken from its mapper
+	gotQuery, _ := Fingerprint(From(u).Selectx(func(row *Row) { row.Int(u.USER_ID) }, nil))
+	is.Equal("SELECT u.user_id FROM devlab.users AS u", gotQuery)
+}
@@ -0,0 +1,13 @@
+package sq
+
+// Grouping represents the GROUPING() function, which returns a bit mask of
+// the fields that are not part of the current grouping. Use it with
+// SelectQuery.WithRollup to tell subtotal rows apart from rows where the field
+// is actually NULL.
+func Grouping(fields ...Field) NumberField {
+	format := "GROUPING(?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{Fields(fields)},
+	}
+}
@@ -0,0 +1,23 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGrouping_WithRollup(t *testing.T) {
+	is := is.New(t)
+	ur := USER_ROLES().As("ur")
+	gotQuery, gotArgs := From(ur).
+		Where(ur.DELETED_AT.IsNull()).
+		GroupBy(ur.COHORT, ur.ROLE).
+		WithRollup().
+		Having(Count().GtInt(1)).
+		Select(ur.COHORT, ur.ROLE, Grouping(ur.COHORT, ur.ROLE).As("subtotal_level"), Count()).
+		ToSQL()
+	is.Equal("SELECT ur.cohort, ur.role, GROUPING(ur.cohort, ur.role) AS subtotal_level, COUNT(*)"+
+		" FROM devlab.user_roles AS ur WHERE ur.deleted_at IS NULL"+
+		" GROUP BY ur.cohort, ur.role WITH ROLLUP HAVING COUNT(*) > ?", gotQuery)
+	is.Equal([]interface{}{1}, gotArgs)
+}
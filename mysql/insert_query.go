@@ -14,6 +14,7 @@ import (
 // InsertQuery represents an INSERT query.
 type InsertQuery struct {
 	nested bool
+	format sqlFormat
 	Alias  string
 	// INSERT INTO
 	Ignore        bool
@@ -28,6 +29,9 @@ type InsertQuery struct {
 	// DB
 	DB           DB
 	ColumnMapper func(*Column)
+	// AutoValidate makes Fetch and Exec call Validate before running the
+	// query.
+	AutoValidate bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -85,30 +89,35 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	// VALUES/SELECT
 	switch {
 	case len(q.RowValues) > 0:
-		buf.WriteString(" VALUES ")
+		q.format.newline(buf)
+		buf.WriteString("VALUES ")
 		q.RowValues.AppendSQL(buf, args, nil)
 	case q.SelectQuery != nil:
-		buf.WriteString(" ")
+		q.format.newline(buf)
 		q.SelectQuery.nested = true
-		q.SelectQuery.AppendSQL(buf, args, nil)
+		q.format.nest(*q.SelectQuery).AppendSQL(buf, args, nil)
 	}
 	// ON DUPLICATE KEY UPDATE
 	if len(q.Resolution) > 0 {
-		buf.WriteString(" ON DUPLICATE KEY UPDATE ")
+		q.format.newline(buf)
+		buf.WriteString("ON DUPLICATE KEY UPDATE ")
 		q.Resolution.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
 	}
 	if !q.nested {
 		if q.Log != nil {
-			query := buf.String()
+			query, logArgs := buf.String(), *args
+			if Lpretty&q.LogFlag != 0 && !q.format.pretty {
+				query, logArgs = prettyLogQuery(q)
+			}
 			var logOutput string
 			switch {
 			case Lstats&q.LogFlag != 0:
-				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(*args) +
-					"\n----[ with bind values ]----\n" + questionInterpolate(query, *args...)
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(logArgs) +
+					"\n----[ with bind values ]----\n" + questionInterpolate(query, logArgs...)
 			case Linterpolate&q.LogFlag != 0:
-				logOutput = "Executing query: " + questionInterpolate(query, *args...)
+				logOutput = "Executing query: " + questionInterpolate(query, logArgs...)
 			default:
-				logOutput = "Executing query: " + query + " " + fmt.Sprint(*args)
+				logOutput = "Executing query: " + query + " " + fmt.Sprint(logArgs)
 			}
 			switch q.Log.(type) {
 			case *log.Logger:
@@ -211,6 +220,11 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (las
 		}
 		db = q.DB
 	}
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return lastInsertID, rowsAffected, err
+		}
+	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
 	defer func() {
@@ -227,6 +241,7 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (las
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, rowsAffected, elapsed, err)
 		if Lstats&q.LogFlag != 0 && ErowsAffected&flag != 0 {
 			logBuf.WriteString("\n(Inserted ")
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
@@ -248,6 +263,7 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (las
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
@@ -0,0 +1,275 @@
+package sq
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interpolate replaces the question mark ? placeholders in a query with their
+// args, returning SQL that can be run as-is in the mysql client. Strings are
+// escaped (assuming the default sql_mode without NO_BACKSLASH_ESCAPES),
+// []byte and [16]byte UUIDs are rendered as hex literals and maps, structs and
+// slices as JSON. Times are rendered in UTC, use InterpolateIn for a different
+// session time zone. Placeholders inside string literals, quoted identifiers
+// and comments are left alone.
+//
+// Interpolate returns an error if the number of placeholders and args differ
+// or an arg cannot be represented in SQL.
+func Interpolate(query string, args []interface{}) (string, error) {
+	return InterpolateIn(time.UTC, query, args)
+}
+
+// InterpolateIn is like Interpolate, but renders times in the loc time zone.
+// loc should be the time_zone of the session that the SQL will be run in, as
+// DATETIME literals carry no time zone.
+func InterpolateIn(loc *time.Location, query string, args []interface{}) (string, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	buf := &strings.Builder{}
+	argIndex := 0
+	for i := 0; i < len(query); {
+		n := skipQuoted(query, i)
+		if n > i {
+			buf.WriteString(query[i:n])
+			i = n
+			continue
+		}
+		if query[i] != '?' {
+			buf.WriteByte(query[i])
+			i++
+			continue
+		}
+		if argIndex >= len(args) {
+			return "", fmt.Errorf("sq: query has more placeholders than args (%d args)", len(args))
+		}
+		err := interpolateValue(buf, loc, args[argIndex])
+		if err != nil {
+			return "", fmt.Errorf("sq: placeholder %d: %w", argIndex+1, err)
+		}
+		argIndex++
+		i++
+	}
+	if argIndex < len(args) {
+		return "", fmt.Errorf("sq: query has %d placeholders but %d args", argIndex, len(args))
+	}
+	return buf.String(), nil
+}
+
+// ToSQLInterpolated returns the SelectQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q SelectQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the InsertQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q InsertQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the UpdateQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q UpdateQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the DeleteQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q DeleteQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the VariadicQuery as SQL with its args
+// interpolated. See Interpolate.
+func (vq VariadicQuery) ToSQLInterpolated() (string, error) {
+	query, args := vq.ToSQL()
+	return Interpolate(query, args)
+}
+
+// skipQuoted returns the index just past the string literal, quoted
+// identifier or comment starting at query[i], or i if there is none.
+func skipQuoted(query string, i int) int {
+	rest := query[i:]
+	switch {
+	case strings.HasPrefix(rest, "-- "), rest[0] == '#':
+		if n := strings.IndexByte(rest, '\n'); n >= 0 {
+			return i + n + 1
+		}
+		return len(query)
+	case strings.HasPrefix(rest, "/*"):
+		if n := strings.Index(rest[2:], "*/"); n >= 0 {
+			return i + 2 + n + 2
+		}
+		return len(query)
+	case rest[0] == '\'', rest[0] == '"', rest[0] == '`':
+		quote := rest[0]
+		for j := i + 1; j < len(query); j++ {
+			switch {
+			case quote != '`' && query[j] == '\\':
+				j++
+			case query[j] == quote:
+				if j+1 < len(query) && query[j+1] == quote {
+					j++
+					continue
+				}
+				return j + 1
+			}
+		}
+		return len(query)
+	}
+	return i
+}
+
+// interpolateValue writes the SQL literal of a value into the buffer.
+func interpolateValue(buf *strings.Builder, loc *time.Location, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("NULL")
+		return nil
+	case json.RawMessage:
+		if v == nil {
+			buf.WriteString("NULL")
+			return nil
+		}
+		quoteString(buf, string(v))
+		return nil
+	case []byte:
+		if v == nil {
+			buf.WriteString("NULL")
+			return nil
+		}
+		buf.WriteString("X'")
+		buf.WriteString(hex.EncodeToString(v))
+		buf.WriteString("'")
+		return nil
+	case time.Time:
+		buf.WriteString("'")
+		buf.WriteString(v.In(loc).Format("2006-01-02 15:04:05.999999"))
+		buf.WriteString("'")
+		return nil
+	case driver.Valuer:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		Interface, err := v.Value()
+		if err != nil {
+			return err
+		}
+		if _, ok := Interface.(driver.Valuer); ok {
+			return fmt.Errorf("%T.Value returned another driver.Valuer", v)
+		}
+		return interpolateValue(buf, loc, Interface)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		return interpolateValue(buf, loc, rv.Elem().Interface())
+	case reflect.Bool:
+		if rv.Bool() {
+			buf.WriteString("TRUE")
+		} else {
+			buf.WriteString("FALSE")
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeNumber(buf, strconv.FormatInt(rv.Int(), 10))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		buf.WriteString(strconv.FormatUint(rv.Uint(), 10))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("MySQL does not support the float %v", f)
+		}
+		writeNumber(buf, strconv.FormatFloat(f, 'g', -1, rv.Type().Bits()))
+		return nil
+	case reflect.String:
+		quoteString(buf, rv.String())
+		return nil
+	case reflect.Array:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return interpolateValue(buf, loc, b)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return interpolateValue(buf, loc, rv.Bytes())
+		}
+		if rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		quoteString(buf, string(b))
+		return nil
+	case reflect.Map, reflect.Struct:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		quoteString(buf, string(b))
+		return nil
+	}
+	return fmt.Errorf("cannot interpolate value of type %T", value)
+}
+
+// writeNumber writes a number into the buffer, wrapping negative numbers in
+// parentheses so that a preceding minus sign cannot turn them into a comment
+// i.e. '-$1' must not become '--1'.
+func writeNumber(buf *strings.Builder, s string) {
+	if strings.HasPrefix(s, "-") {
+		buf.WriteString("(" + s + ")")
+		return
+	}
+	buf.WriteString(s)
+}
+
+// quoteString writes a string literal into the buffer, backslash escaping
+// quotes, backslashes and the control characters that mysql_real_escape_string
+// escapes.
+func quoteString(buf *strings.Builder, s string) {
+	buf.WriteString("'")
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case 0:
+			buf.WriteString(`\0`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\x1a':
+			buf.WriteString(`\Z`)
+		case '\'':
+			buf.WriteString(`\'`)
+		case '\\':
+			buf.WriteString(`\\`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteString("'")
+}
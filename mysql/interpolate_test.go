@@ -0,0 +1,110 @@
+package sq
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestInterpolate(t *testing.T) {
+	type TT struct {
+		description string
+		query       string
+		args        []interface{}
+		wantQuery   string
+	}
+	sgt := time.FixedZone("SGT", 8*60*60)
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 600000000, sgt)
+	id := [16]byte{0x5e, 0x0e, 0x1d, 0x3a, 0x8e, 0x2b, 0x4a, 0xc4, 0x9f, 0x3e, 0x2b, 0x1c, 0x4d, 0x6f, 0x7a, 0x80}
+	name := "bob"
+	tests := []TT{
+		{"nil", "SELECT ?", []interface{}{nil}, "SELECT NULL"},
+		{"bool", "SELECT ?, ?", []interface{}{true, false}, "SELECT TRUE, FALSE"},
+		{"numbers", "SELECT ?, ?, ?", []interface{}{-1, uint8(2), 1.5}, "SELECT (-1), 2, 1.5"},
+		{"negative numbers", "SELECT (-?), -?", []interface{}{-5, -2.5}, "SELECT (-(-5)), -(-2.5)"},
+		{"string", "SELECT ?", []interface{}{"it's"}, `SELECT 'it\'s'`},
+		{"string injection", "SELECT ?", []interface{}{`\'; DROP TABLE users; -- `}, `SELECT '\\\'; DROP TABLE users; -- '`},
+		{"control characters", "SELECT ?", []interface{}{"a\x00b\nc\r\x1a"}, `SELECT 'a\0b\nc\r\Z'`},
+		{"bytes", "SELECT ?", []interface{}{[]byte{0xde, 0xad}}, "SELECT X'dead'"},
+		{"nil bytes", "SELECT ?", []interface{}{[]byte(nil)}, "SELECT NULL"},
+		{"uuid", "SELECT ?", []interface{}{id}, "SELECT X'5e0e1d3a8e2b4ac49f3e2b1c4d6f7a80'"},
+		{"time", "SELECT ?", []interface{}{ts}, "SELECT '2020-01-01 19:04:05.6'"},
+		{"JSON", "SELECT ?, ?, ?", []interface{}{map[string]string{"a": "it's"}, []int{1, 2}, json.RawMessage(`[1]`)}, `SELECT '{"a":"it\'s"}', '[1,2]', '[1]'`},
+		{"valuer", "SELECT ?, ?", []interface{}{sql.NullString{}, sql.NullInt64{Int64: 5, Valid: true}}, "SELECT NULL, 5"},
+		{"pointer", "SELECT ?, ?", []interface{}{&name, (*string)(nil)}, "SELECT 'bob', NULL"},
+		{
+			"placeholders in quotes and comments",
+			"SELECT '?', \"\\\" ?\", `?`, ? -- ?\n# ?\n/* ? */",
+			[]interface{}{1},
+			"SELECT '?', \"\\\" ?\", `?`, 1 -- ?\n# ?\n/* ? */",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, err := Interpolate(tt.query, tt.args)
+			is.NoErr(err)
+			is.Equal(tt.wantQuery, gotQuery)
+		})
+	}
+}
+
+func TestInterpolate_Errors(t *testing.T) {
+	type TT struct {
+		description string
+		query       string
+		args        []interface{}
+		wantErr     string
+	}
+	tests := []TT{
+		{"missing arg", "SELECT ?, ?", []interface{}{1}, "sq: query has more placeholders than args (1 args)"},
+		{"extra arg", "SELECT ?", []interface{}{1, 2}, "sq: query has 1 placeholders but 2 args"},
+		{"unsupported type", "SELECT ?", []interface{}{make(chan int)}, "sq: placeholder 1: cannot interpolate value of type chan int"},
+		{"NaN", "SELECT ?", []interface{}{math.NaN()}, "sq: placeholder 1: MySQL does not support the float NaN"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			_, err := Interpolate(tt.query, tt.args)
+			is.True(err != nil)
+			is.Equal(tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestInterpolateIn(t *testing.T) {
+	is := is.New(t)
+	sgt := time.FixedZone("SGT", 8*60*60)
+	ts := time.Date(2020, 1, 1, 19, 4, 5, 0, time.UTC)
+	gotQuery, err := InterpolateIn(sgt, "SELECT ?", []interface{}{ts})
+	is.NoErr(err)
+	is.Equal("SELECT '2020-01-02 03:04:05'", gotQuery)
+}
+
+func TestToSQLInterpolated(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	gotQuery, err := From(u).
+		Where(u.DISPLAYNAME.EqString("O'Brien"), u.USER_ID.In([]int{1, 2})).
+		Select(u.USER_ID).
+		ToSQLInterpolated()
+	is.NoErr(err)
+	is.Equal(`SELECT u.user_id FROM devlab.users AS u WHERE u.displayname = 'O\'Brien' AND u.user_id IN (1, 2)`, gotQuery)
+	gotQuery, err = InsertInto(u).Columns(u.EMAIL, u.PASSWORD).Values("bob@email.com", nil).ToSQLInterpolated()
+	is.NoErr(err)
+	is.Equal("INSERT INTO devlab.users (email, password) VALUES ('bob@email.com', NULL)", gotQuery)
+	gotQuery, err = Union(
+		Select(Int(1)),
+		Select(String("a\nb")),
+	).ToSQLInterpolated()
+	is.NoErr(err)
+	is.Equal(`SELECT 1 UNION SELECT 'a\nb'`, gotQuery)
+}
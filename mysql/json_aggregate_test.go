@@ -0,0 +1,173 @@
+package sq
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestJSONAggregate_AppendSQLExclude(t *testing.T) {
+	type TT struct {
+		description string
+		f           JSONAggregate
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	m := MEDIA().As("m")
+	var media struct {
+		Name string
+		Data []byte
+	}
+	mapper := func(row *Row) {
+		media.Name = row.String(m.NAME)
+		row.ScanInto(&media.Data, m.DATA)
+	}
+	tests := []TT{
+		{
+			"basic",
+			JSONAgg(From(m).Where(m.NAME.Eq(u.DISPLAYNAME)).SelectRowx(mapper)),
+			"(SELECT COALESCE(JSON_ARRAYAGG(JSON_OBJECT('0', m.name, '1', HEX(m.data))), JSON_ARRAY())" +
+				" FROM devlab.media AS m WHERE m.name = u.displayname)",
+			nil,
+		},
+		{
+			"ORDER BY wraps the query in a derived table",
+			JSONAgg(From(m).Where(m.NAME.EqString("a")).OrderBy(m.NAME).Limit(5).SelectRowx(mapper)),
+			"(SELECT COALESCE(JSON_ARRAYAGG(json_rows.json_row), JSON_ARRAY()) FROM" +
+				" (SELECT JSON_OBJECT('0', m.name, '1', HEX(m.data), 'n', ROW_NUMBER() OVER (ORDER BY m.name)) AS json_row" +
+				" FROM devlab.media AS m WHERE m.name = ? ORDER BY m.name LIMIT ?) AS json_rows)",
+			[]interface{}{"a", int64(5)},
+		},
+		{
+			"LIMIT without ORDER BY",
+			JSONAgg(From(m).Limit(5).SelectRowx(mapper)),
+			"(SELECT COALESCE(JSON_ARRAYAGG(json_rows.json_row), JSON_ARRAY()) FROM" +
+				" (SELECT JSON_OBJECT('0', m.name, '1', HEX(m.data)) AS json_row" +
+				" FROM devlab.media AS m LIMIT ?) AS json_rows)",
+			[]interface{}{int64(5)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			var _ Field = tt.f
+			tt.f.AppendSQLExclude(buf, &args, nil, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestJSONAggregate_scan(t *testing.T) {
+	is := is.New(t)
+	m := MEDIA()
+	type Media struct {
+		Name      string
+		Valid     bool
+		Data      []byte
+		CreatedAt time.Time
+		DeletedAt sql.NullTime
+		Size      int
+		UUID      [16]byte
+		RawUUID   []byte
+	}
+	var media Media
+	var medias []Media
+	agg := JSONAgg(From(m).Selectx(func(row *Row) {
+		media.Name = row.String(m.NAME)
+		media.Valid = row.StringValid(m.DESCRIPTION)
+		row.ScanInto(&media.Data, m.DATA)
+		media.CreatedAt = row.Time(m.CREATED_AT)
+		media.DeletedAt = row.NullTime(m.DELETED_AT)
+		media.Size = row.Int(NumberFieldf("LENGTH(?)", m.DATA))
+		media.UUID = row.UUID(m.UUID)
+		row.ScanInto(&media.RawUUID, m.UUID)
+	}, func() {
+		medias = append(medias, media)
+	}))
+	err := agg.scan([]byte(`[` +
+		`{"0": "a.png", "1": null, "2": "0102FF", "3": "2020-06-24 16:33:10.123456", "4": null, "5": 3, "6": "5E0E1D3A8E2B4AC49F3E2B1C4D6F7A80", "7": "5E0E1D3A8E2B4AC49F3E2B1C4D6F7A80"},` +
+		`{"0": "b.png", "1": "desc", "2": null, "3": "2020-06-25 08:00:00.000000", "4": "2020-06-26", "5": 0, "6": null, "7": null}` +
+		`]`))
+	is.NoErr(err)
+	is.Equal(2, len(medias))
+	is.Equal("a.png", medias[0].Name)
+	is.Equal(false, medias[0].Valid)
+	is.Equal([]byte{1, 2, 255}, medias[0].Data)
+	is.Equal(time.Date(2020, 6, 24, 16, 33, 10, 123456000, time.UTC), medias[0].CreatedAt)
+	is.Equal(false, medias[0].DeletedAt.Valid)
+	is.Equal(3, medias[0].Size)
+	uuid := [16]byte{0x5e, 0x0e, 0x1d, 0x3a, 0x8e, 0x2b, 0x4a, 0xc4, 0x9f, 0x3e, 0x2b, 0x1c, 0x4d, 0x6f, 0x7a, 0x80}
+	is.Equal(uuid, medias[0].UUID)
+	is.Equal(uuid[:], medias[0].RawUUID)
+	is.Equal("b.png", medias[1].Name)
+	is.Equal(true, medias[1].Valid)
+	is.Equal([]byte(nil), medias[1].Data)
+	is.Equal(time.Date(2020, 6, 25, 8, 0, 0, 0, time.UTC), medias[1].CreatedAt)
+	is.Equal(sql.NullTime{Time: time.Date(2020, 6, 26, 0, 0, 0, 0, time.UTC), Valid: true}, medias[1].DeletedAt)
+	is.Equal([16]byte{}, medias[1].UUID)
+	is.Equal([]byte(nil), medias[1].RawUUID)
+
+	// Objects are scanned in the order of their row numbers
+	medias = nil
+	err = agg.scan([]byte(`[{"0": "c.png", "n": 3}, {"0": "a.png", "n": 1}, {"0": "b.png", "n": 2}]`))
+	is.NoErr(err)
+	is.Equal(3, len(medias))
+	is.Equal("a.png", medias[0].Name)
+	is.Equal("b.png", medias[1].Name)
+	is.Equal("c.png", medias[2].Name)
+
+	// Invalid JSON
+	err = agg.scan([]byte(`{`))
+	is.True(err != nil)
+
+	// Invalid hex
+	err = agg.scan([]byte(`[{"2": "xyz"}]`))
+	is.True(err != nil)
+}
+
+func TestJSONAggregate_Fetch(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "JSONAggregate_Fetch")
+	is.NoErr(err)
+	defer db.Close()
+	u, s := USERS().As("u"), SESSIONS().As("s")
+	type User struct {
+		UserID int
+		Hashes []string
+	}
+	var hash string
+	var hashes []string
+	agg := JSONAgg(From(s).Where(s.USER_ID.Eq(u.USER_ID)).OrderBy(s.HASH).Selectx(func(row *Row) {
+		hash = row.String(s.HASH)
+	}, func() {
+		hashes = append(hashes, hash)
+	}))
+	var user User
+	var users []User
+	err = From(u).OrderBy(u.USER_ID).Limit(5).Selectx(func(row *Row) {
+		hashes = nil
+		user.UserID = row.Int(u.USER_ID)
+		row.ScanJSONAgg(agg)
+		user.Hashes = hashes
+	}, func() {
+		users = append(users, user)
+	}).Fetch(db)
+	is.NoErr(err)
+	is.Equal(5, len(users))
+	for _, user := range users {
+		is.True(sort.StringsAreSorted(user.Hashes))
+	}
+}
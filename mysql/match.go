@@ -0,0 +1,124 @@
+package sq
+
+import "strings"
+
+// MatchFields represents the column list of a MATCH (col1, col2, ...)
+// full-text search. Call Against on it to obtain a MatchPredicate.
+type MatchFields []Field
+
+// Match starts a full-text search over the FULLTEXT indexed fields.
+func Match(fields ...Field) MatchFields {
+	return fields
+}
+
+// Against returns a MatchPredicate searching the MatchFields for the query
+// i.e. 'MATCH (fields) AGAINST (query)'.
+func (fs MatchFields) Against(query interface{}) MatchPredicate {
+	return MatchPredicate{
+		fields: Fields(fs),
+		query:  query,
+	}
+}
+
+// MatchPredicate represents the MATCH (col1, col2, ...) AGAINST (expr
+// [search_modifier]) full-text search function. It can be used as a Predicate
+// in WHERE or as a relevance score in SELECT and ORDER BY.
+type MatchPredicate struct {
+	alias     string
+	fields    Fields
+	query     interface{}
+	mode      string
+	expansion bool
+	negative  bool
+}
+
+// AppendSQLExclude marshals the MatchPredicate into a buffer and an args slice.
+// It propagates the excludedTableQualifiers down to its fields.
+func (p MatchPredicate) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	if p.negative {
+		buf.WriteString("NOT ")
+	}
+	buf.WriteString("MATCH (")
+	p.fields.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
+	buf.WriteString(") AGAINST (")
+	appendSQLValue(buf, args, excludedTableQualifiers, p.query)
+	if p.mode != "" {
+		buf.WriteString(" ")
+		buf.WriteString(p.mode)
+	}
+	if p.expansion {
+		buf.WriteString(" WITH QUERY EXPANSION")
+	}
+	buf.WriteString(")")
+}
+
+// InNaturalLanguageMode sets the search modifier to IN NATURAL LANGUAGE MODE.
+func (p MatchPredicate) InNaturalLanguageMode() MatchPredicate {
+	p.mode = "IN NATURAL LANGUAGE MODE"
+	return p
+}
+
+// InBooleanMode sets the search modifier to IN BOOLEAN MODE. Boolean mode
+// cannot be combined with query expansion, so it clears WITH QUERY EXPANSION.
+func (p MatchPredicate) InBooleanMode() MatchPredicate {
+	p.mode = "IN BOOLEAN MODE"
+	p.expansion = false
+	return p
+}
+
+// WithQueryExpansion adds the WITH QUERY EXPANSION search modifier. Query
+// expansion cannot be combined with boolean mode, so it clears IN BOOLEAN
+// MODE.
+func (p MatchPredicate) WithQueryExpansion() MatchPredicate {
+	if p.mode == "IN BOOLEAN MODE" {
+		p.mode = ""
+	}
+	p.expansion = true
+	return p
+}
+
+// Score returns the MatchPredicate as a NumberField i.e. the relevance score of
+// the full-text search, for use in comparisons and arithmetic.
+func (p MatchPredicate) Score() NumberField {
+	p.alias = ""
+	format := "?"
+	return NumberField{
+		format: &format,
+		values: []interface{}{p},
+	}
+}
+
+// Asc returns the relevance score of the MatchPredicate sorted in ascending
+// order.
+func (p MatchPredicate) Asc() NumberField {
+	return p.Score().Asc()
+}
+
+// Desc returns the relevance score of the MatchPredicate sorted in descending
+// order.
+func (p MatchPredicate) Desc() NumberField {
+	return p.Score().Desc()
+}
+
+// As aliases the MatchPredicate i.e. 'MATCH (...) AGAINST (...) AS alias'.
+func (p MatchPredicate) As(alias string) MatchPredicate {
+	p.alias = alias
+	return p
+}
+
+// Not inverts the MatchPredicate i.e. 'NOT MATCH (...) AGAINST (...)'.
+func (p MatchPredicate) Not() Predicate {
+	p.negative = !p.negative
+	return p
+}
+
+// GetAlias returns the alias of the MatchPredicate.
+func (p MatchPredicate) GetAlias() string {
+	return p.alias
+}
+
+// GetName returns the name of the MatchPredicate, which is always an empty
+// string.
+func (p MatchPredicate) GetName() string {
+	return ""
+}
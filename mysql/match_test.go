@@ -0,0 +1,134 @@
+package sq
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMatchPredicate_AppendSQLExclude(t *testing.T) {
+	type TT struct {
+		description string
+		p           Predicate
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	m := MEDIA().As("m")
+	tests := []TT{
+		{
+			"no search modifier",
+			Match(m.NAME, m.DESCRIPTION).Against("cat"),
+			nil,
+			"MATCH (m.name, m.description) AGAINST (?)",
+			[]interface{}{"cat"},
+		},
+		{
+			"InNaturalLanguageMode",
+			Match(m.NAME).Against("cat").InNaturalLanguageMode(),
+			[]string{"m"},
+			"MATCH (name) AGAINST (? IN NATURAL LANGUAGE MODE)",
+			[]interface{}{"cat"},
+		},
+		{
+			"InBooleanMode",
+			Match(m.NAME, m.DESCRIPTION).Against("+cat -dog").InBooleanMode(),
+			nil,
+			"MATCH (m.name, m.description) AGAINST (? IN BOOLEAN MODE)",
+			[]interface{}{"+cat -dog"},
+		},
+		{
+			"WithQueryExpansion",
+			Match(m.NAME).Against("cat").WithQueryExpansion(),
+			nil,
+			"MATCH (m.name) AGAINST (? WITH QUERY EXPANSION)",
+			[]interface{}{"cat"},
+		},
+		{
+			"InNaturalLanguageMode WithQueryExpansion",
+			Match(m.NAME).Against("cat").InNaturalLanguageMode().WithQueryExpansion(),
+			nil,
+			"MATCH (m.name) AGAINST (? IN NATURAL LANGUAGE MODE WITH QUERY EXPANSION)",
+			[]interface{}{"cat"},
+		},
+		{
+			"InBooleanMode clears WithQueryExpansion",
+			Match(m.NAME).Against("cat").WithQueryExpansion().InBooleanMode(),
+			nil,
+			"MATCH (m.name) AGAINST (? IN BOOLEAN MODE)",
+			[]interface{}{"cat"},
+		},
+		{
+			"Not",
+			Match(m.NAME).Against("cat").InBooleanMode().Not(),
+			nil,
+			"NOT MATCH (m.name) AGAINST (? IN BOOLEAN MODE)",
+			[]interface{}{"cat"},
+		},
+		{
+			"Score",
+			Match(m.NAME).Against("cat").Score().GtFloat64(0.5),
+			nil,
+			"MATCH (m.name) AGAINST (?) > ?",
+			[]interface{}{"cat", 0.5},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.p.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestMatchPredicate_Select(t *testing.T) {
+	is := is.New(t)
+	m := MEDIA().As("m")
+	match := Match(m.NAME, m.DESCRIPTION).Against("cat").InNaturalLanguageMode()
+	gotQuery, gotArgs := From(m).
+		Where(match).
+		OrderBy(match.Desc()).
+		Select(m.NAME, match.As("score")).
+		ToSQL()
+	is.Equal("SELECT m.name, MATCH (m.name, m.description) AGAINST (? IN NATURAL LANGUAGE MODE) AS score"+
+		" FROM devlab.media AS m"+
+		" WHERE MATCH (m.name, m.description) AGAINST (? IN NATURAL LANGUAGE MODE)"+
+		" ORDER BY MATCH (m.name, m.description) AGAINST (? IN NATURAL LANGUAGE MODE) DESC", gotQuery)
+	is.Equal([]interface{}{"cat", "cat", "cat"}, gotArgs)
+}
+
+func TestMatchPredicate_Fetch(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "MatchPredicate_Fetch")
+	is.NoErr(err)
+	defer db.Close()
+	m := MEDIA().As("m")
+	match := Match(m.NAME, m.DESCRIPTION).Against("+image* -video*").InBooleanMode()
+	var score float64
+	var scores []float64
+	err = From(m).
+		Where(match).
+		OrderBy(match.Desc()).
+		Limit(5).
+		Selectx(func(row *Row) {
+			score = row.Float64(match.Score())
+		}, func() {
+			scores = append(scores, score)
+		}).
+		Fetch(db)
+	is.NoErr(err)
+	for i := 1; i < len(scores); i++ {
+		is.True(scores[i-1] >= scores[i])
+	}
+}
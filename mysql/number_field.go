@@ -336,6 +336,59 @@ func (f NumberField) LeInt(num int) Predicate {
 	}
 }
 
+// Between returns an 'X BETWEEN Y AND Z' Predicate. It only accepts
+// NumberField.
+func (f NumberField) Between(start, end NumberField) Predicate {
+	return CustomPredicate{
+		Format: "? BETWEEN ? AND ?",
+		Values: []interface{}{f, start, end},
+	}
+}
+
+// BetweenInt returns an 'X BETWEEN Y AND Z' Predicate. It only accepts int.
+func (f NumberField) BetweenInt(start, end int) Predicate {
+	return CustomPredicate{
+		Format: "? BETWEEN ? AND ?",
+		Values: []interface{}{f, start, end},
+	}
+}
+
+// BetweenFloat64 returns an 'X BETWEEN Y AND Z' Predicate. It only accepts
+// float64.
+func (f NumberField) BetweenFloat64(start, end float64) Predicate {
+	return CustomPredicate{
+		Format: "? BETWEEN ? AND ?",
+		Values: []interface{}{f, start, end},
+	}
+}
+
+// NotBetween returns an 'X NOT BETWEEN Y AND Z' Predicate. It only accepts
+// NumberField.
+func (f NumberField) NotBetween(start, end NumberField) Predicate {
+	return CustomPredicate{
+		Format: "? NOT BETWEEN ? AND ?",
+		Values: []interface{}{f, start, end},
+	}
+}
+
+// NotBetweenInt returns an 'X NOT BETWEEN Y AND Z' Predicate. It only accepts
+// int.
+func (f NumberField) NotBetweenInt(start, end int) Predicate {
+	return CustomPredicate{
+		Format: "? NOT BETWEEN ? AND ?",
+		Values: []interface{}{f, start, end},
+	}
+}
+
+// NotBetweenFloat64 returns an 'X NOT BETWEEN Y AND Z' Predicate. It only
+// accepts float64.
+func (f NumberField) NotBetweenFloat64(start, end float64) Predicate {
+	return CustomPredicate{
+		Format: "? NOT BETWEEN ? AND ?",
+		Values: []interface{}{f, start, end},
+	}
+}
+
 // In returns an 'X IN (Y)' Predicate.
 func (f NumberField) In(v interface{}) Predicate {
 	var format string
@@ -351,6 +404,27 @@ func (f NumberField) In(v interface{}) Predicate {
 	}
 }
 
+// NotIn returns an 'X NOT IN (Y)' Predicate.
+func (f NumberField) NotIn(v interface{}) Predicate {
+	var format string
+	var values []interface{}
+	switch v := v.(type) {
+	case RowValue:
+		format = "? NOT IN ?"
+		values = []interface{}{f, v}
+	case Query:
+		format = "? NOT IN (?)"
+		values = []interface{}{f, v.NestThis()}
+	default:
+		format = "? NOT IN (?)"
+		values = []interface{}{f, v}
+	}
+	return CustomPredicate{
+		Format: format,
+		Values: values,
+	}
+}
+
 // String returns the string representation of the NumberField.
 func (f NumberField) String() string {
 	buf := &strings.Builder{}
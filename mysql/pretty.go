@@ -0,0 +1,303 @@
+package sq
+
+import "strings"
+
+// sqlFormat is how a query lays out its SQL. The zero value writes the whole
+// query on one line, which is what ToSQL does. A pretty sqlFormat starts each
+// clause on a new line and indents subqueries, CTEs, join conditions and
+// AND/OR groups by one indent per level of nesting.
+type sqlFormat struct {
+	pretty bool
+	indent string
+	depth  int
+}
+
+// plain reports whether the sqlFormat is the zero value.
+func (f sqlFormat) plain() bool {
+	return !f.pretty
+}
+
+// defaultIndent is the indent used for the Lpretty log output.
+const defaultIndent = "    "
+
+// prettyLogQuery returns the query pretty printed with the defaultIndent, for
+// the Lpretty log output. The query itself is still run as a single line.
+func prettyLogQuery(q Query) (string, []interface{}) {
+	f := sqlFormat{pretty: true, indent: defaultIndent}
+	switch v := q.(type) {
+	case SelectQuery:
+		v.format, v.Log = f, nil
+		return v.ToSQL()
+	case InsertQuery:
+		v.format, v.Log = f, nil
+		return v.ToSQL()
+	case UpdateQuery:
+		v.format, v.Log = f, nil
+		return v.ToSQL()
+	case DeleteQuery:
+		v.format, v.Log = f, nil
+		return v.ToSQL()
+	case VariadicQuery:
+		v.format, v.Log = f, nil
+		return v.ToSQL()
+	}
+	return q.ToSQL()
+}
+
+// newline writes the whitespace that separates two clauses: a space, or a
+// newline followed by the indentation when pretty printing.
+func (f sqlFormat) newline(buf *strings.Builder) {
+	if !f.pretty {
+		buf.WriteString(" ")
+		return
+	}
+	buf.WriteString("\n")
+	buf.WriteString(strings.Repeat(f.indent, f.depth))
+}
+
+// indented returns the sqlFormat one level of nesting deeper.
+func (f sqlFormat) indented() sqlFormat {
+	if f.pretty {
+		f.depth++
+	}
+	return f
+}
+
+// nest marks a query as nested and hands it the sqlFormat.
+func (f sqlFormat) nest(q Query) Query {
+	if f.plain() {
+		return q.NestThis()
+	}
+	switch q := q.(type) {
+	case SelectQuery:
+		q.nested, q.format = true, f
+		return q
+	case InsertQuery:
+		q.nested, q.format = true, f
+		return q
+	case UpdateQuery:
+		q.nested, q.format = true, f
+		return q
+	case DeleteQuery:
+		q.nested, q.format = true, f
+		return q
+	case VariadicQuery:
+		q.nested, q.format = true, f
+		return q
+	case Subquery:
+		if inner := q.GetQuery(); inner != nil {
+			return f.nest(inner)
+		}
+	}
+	return q.NestThis()
+}
+
+// appendQuery writes a query that is nested inside parentheses. When pretty
+// printing, the query starts on a new line one level deeper and the closing
+// parenthesis goes on its own line.
+func (f sqlFormat) appendQuery(buf *strings.Builder, args *[]interface{}, q Query) {
+	if f.plain() {
+		q.NestThis().AppendSQL(buf, args, nil)
+		return
+	}
+	inner := f.indented()
+	if f.pretty {
+		inner.newline(buf)
+	}
+	inner.nest(q).AppendSQL(buf, args, nil)
+	if f.pretty {
+		f.newline(buf)
+	}
+}
+
+// appendJoinTables writes the JOIN clauses, each starting on a new line.
+func (f sqlFormat) appendJoinTables(buf *strings.Builder, args *[]interface{}, joins JoinTables) {
+	if f.plain() {
+		buf.WriteString(" ")
+		joins.AppendSQL(buf, args, nil)
+		return
+	}
+	for _, join := range joins {
+		if join.JoinType == "" {
+			join.JoinType = JoinTypeInner
+		}
+		f.newline(buf)
+		buf.WriteString(string(join.JoinType) + " ")
+		switch v := join.Table.(type) {
+		case nil:
+			buf.WriteString("NULL")
+		case Query:
+			buf.WriteString("(")
+			f.appendQuery(buf, args, v)
+			buf.WriteString(")")
+		default:
+			join.Table.AppendSQL(buf, args, nil)
+		}
+		if join.Table != nil {
+			alias := join.Table.GetAlias()
+			if alias != "" {
+				buf.WriteString(" AS ")
+				buf.WriteString(alias)
+			}
+		}
+		if len(join.OnPredicates.Predicates) > 0 {
+			inner := f.indented()
+			inner.newline(buf)
+			buf.WriteString("ON ")
+			join.OnPredicates.toplevel = true
+			inner.appendPredicate(buf, args, join.OnPredicates, nil)
+		}
+	}
+}
+
+// appendPredicate writes a predicate that starts on a line at the sqlFormat's
+// depth. When pretty printing, every predicate of an AND/OR group after the
+// first starts on a new line one level deeper with its operator, and groups
+// nested inside other groups are wrapped in parentheses on their own lines.
+// Subqueries inside CustomPredicates are indented as well.
+func (f sqlFormat) appendPredicate(buf *strings.Builder, args *[]interface{}, predicate Predicate, excludedTableQualifiers []string) {
+	if f.plain() {
+		if predicate == nil {
+			buf.WriteString("NULL")
+			return
+		}
+		predicate.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
+		return
+	}
+	switch p := predicate.(type) {
+	case nil:
+		buf.WriteString("NULL")
+	case CustomPredicate:
+		if p.Negative {
+			buf.WriteString("NOT ")
+		}
+		f.expandValues(buf, args, excludedTableQualifiers, p.Format, p.Values)
+	case VariadicPredicate:
+		if p.Operator == "" {
+			p.Operator = PredicateAnd
+		}
+		switch len(p.Predicates) {
+		case 0: // nothing to do here
+		case 1:
+			if p.Negative {
+				buf.WriteString("NOT ")
+			}
+			switch v := p.Predicates[0].(type) {
+			case VariadicPredicate:
+				if !p.toplevel {
+					buf.WriteString("(")
+				}
+				v.toplevel = true
+				f.appendPredicate(buf, args, v, excludedTableQualifiers)
+				if !p.toplevel {
+					buf.WriteString(")")
+				}
+			default:
+				f.appendPredicate(buf, args, v, excludedTableQualifiers)
+			}
+		default:
+			if p.Negative {
+				buf.WriteString("NOT ")
+			}
+			// A toplevel group continues the line it starts on, any other
+			// group starts on a new line after its opening parenthesis.
+			inner := f.indented()
+			if !p.toplevel {
+				buf.WriteString("(")
+				if f.pretty {
+					inner.newline(buf)
+				}
+			}
+			for i, predicate := range p.Predicates {
+				line := inner
+				if i == 0 && p.toplevel {
+					line = f
+				}
+				if i > 0 {
+					inner.newline(buf)
+					buf.WriteString(string(p.Operator))
+					buf.WriteString(" ")
+				}
+				if v, ok := predicate.(VariadicPredicate); ok {
+					v.toplevel = false
+					predicate = v
+				}
+				line.appendPredicate(buf, args, predicate, excludedTableQualifiers)
+			}
+			if !p.toplevel {
+				if f.pretty {
+					f.newline(buf)
+				}
+				buf.WriteString(")")
+			}
+		}
+	default:
+		p.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
+	}
+}
+
+// expandValues is like the package level expandValues, but indents any
+// queries in the values.
+func (f sqlFormat) expandValues(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string, format string, values []interface{}) {
+	for i := strings.Index(format, "?"); i >= 0 && len(values) > 0; i = strings.Index(format, "?") {
+		buf.WriteString(format[:i])
+		switch v := values[0].(type) {
+		case Query:
+			f.appendQuery(buf, args, v)
+		case Predicate:
+			f.appendPredicate(buf, args, v, excludedTableQualifiers)
+		case CustomField:
+			// AnyOf and AllOf wrap their subqueries in a CustomField
+			if v.IsDesc != nil || v.Format == "" && len(v.Values) == 0 {
+				v.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
+				break
+			}
+			f.expandValues(buf, args, excludedTableQualifiers, v.Format, v.Values)
+		default:
+			appendSQLValue(buf, args, excludedTableQualifiers, v)
+		}
+		format = format[i+1:]
+		values = values[1:]
+	}
+	buf.WriteString(format)
+}
+
+// ToSQLPretty is like ToSQL, but starts each clause on a new line and indents
+// subqueries, CTEs, join conditions and AND/OR groups with the indent string.
+func (q SelectQuery) ToSQLPretty(indent string) (string, []interface{}) {
+	q.logSkip += 1
+	q.format = sqlFormat{pretty: true, indent: indent}
+	return q.ToSQL()
+}
+
+// ToSQLPretty is like ToSQL, but starts each clause on a new line and indents
+// subqueries with the indent string.
+func (q InsertQuery) ToSQLPretty(indent string) (string, []interface{}) {
+	q.logSkip += 1
+	q.format = sqlFormat{pretty: true, indent: indent}
+	return q.ToSQL()
+}
+
+// ToSQLPretty is like ToSQL, but starts each clause on a new line and indents
+// subqueries, CTEs, join conditions and AND/OR groups with the indent string.
+func (q UpdateQuery) ToSQLPretty(indent string) (string, []interface{}) {
+	q.logSkip += 1
+	q.format = sqlFormat{pretty: true, indent: indent}
+	return q.ToSQL()
+}
+
+// ToSQLPretty is like ToSQL, but starts each clause on a new line and indents
+// subqueries, CTEs, join conditions and AND/OR groups with the indent string.
+func (q DeleteQuery) ToSQLPretty(indent string) (string, []interface{}) {
+	q.logSkip += 1
+	q.format = sqlFormat{pretty: true, indent: indent}
+	return q.ToSQL()
+}
+
+// ToSQLPretty is like ToSQL, but puts each query and set operator on its own
+// line and indents nested unions with the indent string.
+func (vq VariadicQuery) ToSQLPretty(indent string) (string, []interface{}) {
+	vq.logSkip += 1
+	vq.format = sqlFormat{pretty: true, indent: indent}
+	return vq.ToSQL()
+}
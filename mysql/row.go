@@ -2,6 +2,7 @@ package sq
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"runtime"
 	"strconv"
@@ -29,13 +30,16 @@ type Row struct {
 	fields  []Field
 	dest    []interface{}
 	tmpdest []interface{}
+	// jsonValues holds the values of the current element when the Row is
+	// being populated from a JSONAggregate instead of from *sql.Rows.
+	jsonValues []json.RawMessage
 }
 
 /* custom */
 
 // ScanInto scans the field into a dest, where dest is a pointer.
 func (r *Row) ScanInto(dest interface{}, field Field) {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		switch dest.(type) {
 		case *bool, *sql.NullBool:
@@ -96,6 +100,14 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 		nulltime := r.dest[r.index].(*sql.NullTime)
 		*ptr = *nulltime
 	default:
+		if r.jsonValues != nil {
+			err := scanJSONValue(dest, r.fields[r.index], r.jsonValues[r.index])
+			if err != nil {
+				_, sourcefile, linenbr, _ := runtime.Caller(1)
+				panic(fmt.Errorf("row.ScanInto failed on %s:%d: %w", sourcefile, linenbr, err))
+			}
+			break
+		}
 		var nothing interface{}
 		if len(r.tmpdest) != len(r.dest) {
 			r.tmpdest = make([]interface{}, len(r.dest))
@@ -114,6 +126,28 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 	r.index++
 }
 
+// ScanJSONAgg decodes the JSON array aggregated by the JSONAggregate. For
+// each element of the array, the JSONAggregate query's mapper function is
+// called with a Row populated from that element, followed by its accumulator
+// function (if any).
+func (r *Row) ScanJSONAgg(agg JSONAggregate) {
+	if r.rows == nil && r.jsonValues == nil {
+		r.fields = append(r.fields, agg)
+		r.dest = append(r.dest, &sql.NullString{})
+		return
+	}
+	nullstring := r.dest[r.index].(*sql.NullString)
+	r.index++
+	if !nullstring.Valid {
+		return
+	}
+	err := agg.scan([]byte(nullstring.String))
+	if err != nil {
+		_, sourcefile, linenbr, _ := runtime.Caller(1)
+		panic(fmt.Errorf("row.ScanJSONAgg failed on %s:%d: %w", sourcefile, linenbr, err))
+	}
+}
+
 /* bool */
 
 // Bool returns the bool value of the Predicate. BooleanFields are considered
@@ -130,7 +164,7 @@ func (r *Row) BoolValid(predicate Predicate) bool {
 
 // NullBool returns the sql.NullBool value of the Predicate.
 func (r *Row) NullBool(predicate Predicate) sql.NullBool {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		buf := &strings.Builder{}
 		var args []interface{}
 		predicate.AppendSQLExclude(buf, &args, nil, nil)
@@ -176,7 +210,7 @@ func rowFloat64Valid(r *Row, field Field) bool {
 
 // rowNullFloat64 returns the sql.NullFloat64 value of the Field.
 func rowNullFloat64(r *Row, field Field) sql.NullFloat64 {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, &sql.NullFloat64{})
 		return sql.NullFloat64{}
@@ -237,7 +271,7 @@ func rowInt64Valid(r *Row, field NumberField) bool {
 
 // rowNullInt64 returns the sql.NullInt64 value of the Field.
 func rowNullInt64(r *Row, field Field) sql.NullInt64 {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, &sql.NullInt64{})
 		return sql.NullInt64{}
@@ -277,7 +311,7 @@ func rowStringValid(r *Row, field Field) bool {
 
 // rowNullString returns the sql.NullString value of the Field.
 func rowNullString(r *Row, field Field) sql.NullString {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, &sql.NullString{})
 		return sql.NullString{}
@@ -316,7 +350,7 @@ func rowTimeValid(r *Row, field Field) bool {
 
 // rowNullTime returns the sql.NullTime value of the Field.
 func rowNullTime(r *Row, field Field) sql.NullTime {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, &sql.NullTime{})
 		return sql.NullTime{}
@@ -325,3 +359,38 @@ func rowNullTime(r *Row, field Field) sql.NullTime {
 	r.index++
 	return *nulltime
 }
+
+/* UUID */
+
+// UUID returns the [16]byte value of the UUIDField. A NULL uuid is returned
+// as the zero value.
+func (r *Row) UUID(field UUIDField) [16]byte {
+	u, _ := rowUUID(r, field)
+	return u
+}
+
+// UUIDValid returns a bool value indicating if the UUIDField is non-NULL.
+func (r *Row) UUIDValid(field UUIDField) bool {
+	_, valid := rowUUID(r, field)
+	return valid
+}
+
+// rowUUID returns the [16]byte value of the Field and whether it is non-NULL.
+func rowUUID(r *Row, field Field) (u [16]byte, valid bool) {
+	if r.rows == nil && r.jsonValues == nil {
+		r.fields = append(r.fields, field)
+		r.dest = append(r.dest, &sql.NullString{})
+		return u, false
+	}
+	nullstring := r.dest[r.index].(*sql.NullString)
+	r.index++
+	if !nullstring.Valid {
+		return u, false
+	}
+	u, err := parseUUID(nullstring.String)
+	if err != nil {
+		_, sourcefile, linenbr, _ := runtime.Caller(2)
+		panic(fmt.Errorf("row.UUID failed on %s:%d: %w", sourcefile, linenbr, err))
+	}
+	return u, true
+}
@@ -37,22 +37,97 @@ func (r RowValue) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, pa
 	buf.WriteString(")")
 }
 
-// In returns an 'X IN (Y)' Predicate.
+// In returns an 'X IN (Y)' Predicate. It accepts a RowValue, a list of
+// RowValues i.e. '(a, b) IN ((1, 2), (3, 4))' or a Query i.e. '(a, b) IN
+// (SELECT x, y ...)'. A RowValue is a single row i.e. '(a, b) IN ((1, 2))',
+// unless X has only one element in which case it is the list of values i.e.
+// '(a) IN (1, 2, 3)'.
 func (r RowValue) In(v interface{}) CustomPredicate {
-	switch v.(type) {
+	return r.in("IN", v)
+}
+
+// NotIn returns an 'X NOT IN (Y)' Predicate. It accepts the same values as In.
+func (r RowValue) NotIn(v interface{}) CustomPredicate {
+	return r.in("NOT IN", v)
+}
+
+func (r RowValue) in(operator string, v interface{}) CustomPredicate {
+	switch v := v.(type) {
 	case RowValue:
+		if len(r) > 1 {
+			return CustomPredicate{
+				Format: "? " + operator + " (?)",
+				Values: []interface{}{r, v},
+			}
+		}
 		return CustomPredicate{
-			Format: "? IN ?",
+			Format: "? " + operator + " ?",
 			Values: []interface{}{r, v},
 		}
+	case Query:
+		return CustomPredicate{
+			Format: "? " + operator + " (?)",
+			Values: []interface{}{r, v.NestThis()},
+		}
 	default:
 		return CustomPredicate{
-			Format: "? IN (?)",
+			Format: "? " + operator + " (?)",
 			Values: []interface{}{r, v},
 		}
 	}
 }
 
+// compare returns an 'X operator Y' Predicate. A Query is wrapped in brackets
+// as a scalar subquery.
+func (r RowValue) compare(operator string, v interface{}) CustomPredicate {
+	if q, ok := v.(Query); ok {
+		return CustomPredicate{
+			Format: "? " + operator + " (?)",
+			Values: []interface{}{r, q.NestThis()},
+		}
+	}
+	return CustomPredicate{
+		Format: "? " + operator + " ?",
+		Values: []interface{}{r, v},
+	}
+}
+
+// Eq returns an 'X = Y' Predicate. It accepts a RowValue, a Query returning a
+// single row, or AnyOf/AllOf.
+func (r RowValue) Eq(v interface{}) CustomPredicate {
+	return r.compare("=", v)
+}
+
+// Ne returns an 'X <> Y' Predicate. It accepts a RowValue, a Query returning a
+// single row, or AnyOf/AllOf.
+func (r RowValue) Ne(v interface{}) CustomPredicate {
+	return r.compare("<>", v)
+}
+
+// Gt returns an 'X > Y' Predicate. Row values are compared left to right. It
+// accepts a RowValue, a Query returning a single row, or AnyOf/AllOf.
+func (r RowValue) Gt(v interface{}) CustomPredicate {
+	return r.compare(">", v)
+}
+
+// Ge returns an 'X >= Y' Predicate. Row values are compared left to right. It
+// accepts a RowValue, a Query returning a single row, or AnyOf/AllOf.
+func (r RowValue) Ge(v interface{}) CustomPredicate {
+	return r.compare(">=", v)
+}
+
+// Lt returns an 'X < Y' Predicate. Row values are compared left to right. It
+// accepts a RowValue, a Query returning a single row, or AnyOf/AllOf.
+func (r RowValue) Lt(v interface{}) CustomPredicate {
+	return r.compare("<", v)
+}
+
+// Le returns an 'X <= Y' Predicate. Row values are compared left to right. It
+// accepts a RowValue, a Query returning a single row, or AnyOf/AllOf.
+func (r RowValue) Le(v interface{}) CustomPredicate {
+	return r.compare("<=", v)
+}
+
 // CustomAssignment is an Assignment that can render itself in an arbitrary way by calling
 // expandValues on its Format and Values.
 type CustomAssignment struct {
@@ -24,6 +24,7 @@ const (
 // SelectQuery represents a SELECT query.
 type SelectQuery struct {
 	nested bool
+	format sqlFormat
 	Alias  string
 	// WITH
 	CTEs []CTE
@@ -36,7 +37,8 @@ type SelectQuery struct {
 	// WHERE
 	WherePredicate VariadicPredicate
 	// GROUP BY
-	GroupByFields Fields
+	GroupByFields     Fields
+	GroupByWithRollup bool
 	// HAVING
 	HavingPredicate VariadicPredicate
 	// WINDOW
@@ -51,6 +53,9 @@ type SelectQuery struct {
 	DB          DB
 	RowMapper   func(*Row)
 	Accumulator func()
+	// AutoValidate makes Fetch and Exec call Validate before running the
+	// query.
+	AutoValidate bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -70,7 +75,7 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
 	// WITH
 	if !q.nested {
-		appendCTEs(buf, args, q.CTEs, q.FromTable, q.JoinTables)
+		appendCTEs(buf, args, q.CTEs, q.FromTable, q.JoinTables, q.format)
 	}
 	// SELECT
 	if q.SelectType == "" {
@@ -83,11 +88,12 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// FROM
 	if q.FromTable != nil {
-		buf.WriteString(" FROM ")
+		q.format.newline(buf)
+		buf.WriteString("FROM ")
 		switch v := q.FromTable.(type) {
 		case Query:
 			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args, nil)
+			q.format.appendQuery(buf, args, v)
 			buf.WriteString(")")
 		default:
 			q.FromTable.AppendSQL(buf, args, nil)
@@ -100,39 +106,47 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
-		buf.WriteString(" ")
-		q.JoinTables.AppendSQL(buf, args, nil)
+		q.format.appendJoinTables(buf, args, q.JoinTables)
 	}
 	// WHERE
 	if len(q.WherePredicate.Predicates) > 0 {
-		buf.WriteString(" WHERE ")
+		q.format.newline(buf)
+		buf.WriteString("WHERE ")
 		q.WherePredicate.toplevel = true
-		q.WherePredicate.AppendSQLExclude(buf, args, nil, nil)
+		q.format.appendPredicate(buf, args, q.WherePredicate, nil)
 	}
 	// GROUP BY
 	if len(q.GroupByFields) > 0 {
-		buf.WriteString(" GROUP BY ")
+		q.format.newline(buf)
+		buf.WriteString("GROUP BY ")
 		q.GroupByFields.AppendSQLExclude(buf, args, nil, nil)
+		if q.GroupByWithRollup {
+			buf.WriteString(" WITH ROLLUP")
+		}
 	}
 	// HAVING
 	if len(q.HavingPredicate.Predicates) > 0 {
-		buf.WriteString(" HAVING ")
+		q.format.newline(buf)
+		buf.WriteString("HAVING ")
 		q.HavingPredicate.toplevel = true
-		q.HavingPredicate.AppendSQLExclude(buf, args, nil, nil)
+		q.format.appendPredicate(buf, args, q.HavingPredicate, nil)
 	}
 	// WINDOW
 	if len(q.Windows) > 0 {
-		buf.WriteString(" WINDOW ")
+		q.format.newline(buf)
+		buf.WriteString("WINDOW ")
 		q.Windows.AppendSQL(buf, args, nil)
 	}
 	// ORDER BY
 	if len(q.OrderByFields) > 0 {
-		buf.WriteString(" ORDER BY ")
+		q.format.newline(buf)
+		buf.WriteString("ORDER BY ")
 		q.OrderByFields.AppendSQLExclude(buf, args, nil, nil)
 	}
 	// LIMIT
 	if q.LimitValue != nil {
-		buf.WriteString(" LIMIT ?")
+		q.format.newline(buf)
+		buf.WriteString("LIMIT ?")
 		if *q.LimitValue < 0 {
 			*q.LimitValue = -*q.LimitValue
 		}
@@ -140,7 +154,8 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// OFFSET
 	if q.OffsetValue != nil {
-		buf.WriteString(" OFFSET ?")
+		q.format.newline(buf)
+		buf.WriteString("OFFSET ?")
 		if *q.OffsetValue < 0 {
 			*q.OffsetValue = -*q.OffsetValue
 		}
@@ -148,16 +163,19 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	if !q.nested {
 		if q.Log != nil {
-			query := buf.String()
+			query, logArgs := buf.String(), *args
+			if Lpretty&q.LogFlag != 0 && !q.format.pretty {
+				query, logArgs = prettyLogQuery(q)
+			}
 			var logOutput string
 			switch {
 			case Lstats&q.LogFlag != 0:
-				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(*args) +
-					"\n----[ with bind values ]----\n" + questionInterpolate(query, *args...)
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(logArgs) +
+					"\n----[ with bind values ]----\n" + questionInterpolate(query, logArgs...)
 			case Linterpolate&q.LogFlag != 0:
-				logOutput = questionInterpolate(query, *args...)
+				logOutput = questionInterpolate(query, logArgs...)
 			default:
-				logOutput = query + " " + fmt.Sprint(*args)
+				logOutput = query + " " + fmt.Sprint(logArgs)
 			}
 			switch q.Log.(type) {
 			case *log.Logger:
@@ -339,6 +357,14 @@ func (q SelectQuery) GroupBy(fields ...Field) SelectQuery {
 	return q
 }
 
+// WithRollup adds the WITH ROLLUP modifier to the GROUP BY clause in the
+// SelectQuery, which adds a subtotal row for each level of the GROUP BY fields
+// and a grand total row.
+func (q SelectQuery) WithRollup() SelectQuery {
+	q.GroupByWithRollup = true
+	return q
+}
+
 // Having appends the predicates to the HAVING clause in the SelectQuery.
 func (q SelectQuery) Having(predicates ...Predicate) SelectQuery {
 	q.HavingPredicate.Predicates = append(q.HavingPredicate.Predicates, predicates...)
@@ -425,6 +451,7 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, int64(rowcount), elapsed, err)
 		if Lresults&q.LogFlag != 0 && rowcount > 5 {
 			logBuf.WriteString("\n...")
 		}
@@ -447,6 +474,11 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.SelectFields = r.fields
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return err
+		}
+	}
 	if len(q.SelectFields) == 0 {
 		q.SelectFields = Fields{FieldLiteral("1")}
 	}
@@ -454,6 +486,7 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
 	} else {
@@ -0,0 +1,129 @@
+package sqtest
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+
+	sq "github.com/bokwoon95/go-structured-query/mysql"
+)
+
+// connector connects a DB's *sql.DB to the DB's Stubs.
+type connector struct {
+	db *DB
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return conn{db: c.db}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("sqtest: the fake driver can only be opened with NewDB")
+}
+
+// conn answers queries with the DB's Stubs. Args are passed to the DB as-is
+// rather than converted into driver.Values, so that Calls returns the args
+// that the query was run with.
+type conn struct {
+	db *DB
+}
+
+func (c conn) Prepare(query string) (driver.Stmt, error) {
+	return stmt{conn: c, query: query}, nil
+}
+
+func (c conn) Close() error { return nil }
+
+func (c conn) Begin() (driver.Tx, error) { return tx{}, nil }
+
+func (c conn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	fingerprint, _ := sq.ContextFingerprint(ctx)
+	stub, err := c.db.call(query, fingerprint, args)
+	if err != nil {
+		return nil, err
+	}
+	if stub.err != nil {
+		return nil, stub.err
+	}
+	return &rows{columns: stub.columns, rows: stub.rows}, nil
+}
+
+func (c conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	fingerprint, _ := sq.ContextFingerprint(ctx)
+	stub, err := c.db.call(query, fingerprint, args)
+	if err != nil {
+		return nil, err
+	}
+	if stub.err != nil {
+		return nil, stub.err
+	}
+	return result{lastInsertID: stub.lastInsertID, rowsAffected: stub.rowsAffected}, nil
+}
+
+type stmt struct {
+	conn  conn
+	query string
+}
+
+func (s stmt) Close() error { return nil }
+
+func (s stmt) NumInput() int { return -1 }
+
+func (s stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, namedValues(args))
+}
+
+func (s stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, namedValues(args))
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	values := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		values[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return values
+}
+
+type tx struct{}
+
+func (tx) Commit() error { return nil }
+
+func (tx) Rollback() error { return nil }
+
+type result struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r result) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+
+func (r result) RowsAffected() (int64, error) { return r.rowsAffected, nil }
+
+type rows struct {
+	columns []string
+	rows    [][]driver.Value
+	i       int
+}
+
+func (r *rows) Columns() []string { return r.columns }
+
+func (r *rows) Close() error { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.i >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.i])
+	r.i++
+	return nil
+}
@@ -0,0 +1,120 @@
+package sqtest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	sq "github.com/bokwoon95/go-structured-query/mysql"
+)
+
+// UpdateGolden makes AssertSQL write the golden files instead of comparing
+// against them. It can be set from a TestMain or an init function in the test
+// package.
+var UpdateGolden bool
+
+var (
+	goldenMu    sync.Mutex
+	goldenCalls = make(map[testing.TB]int)
+)
+
+// AssertSQL compares the query against the golden file testdata/<test
+// name>.golden, failing the test if they differ. The golden file contains the
+// query pretty printed with ToSQLPretty followed by its numbered args as SQL
+// comments, so that changes to the generated SQL show up as readable diffs.
+//
+// Set UpdateGolden to write the golden files instead of comparing against
+// them. sqtest does not register any flags, but if the test package declares
+// its own -update flag e.g. flag.Bool("update", false, "..."), running the
+// tests with -update has the same effect. If AssertSQL is called more than
+// once in the same test, the golden files of the second and later calls are
+// suffixed with _2, _3 and so on.
+func AssertSQL(t testing.TB, query sq.Query) {
+	t.Helper()
+	assertSQL(t, query, updateGolden())
+}
+
+// updateGolden reports whether the golden files should be written, either
+// because UpdateGolden is set or because an -update flag is set.
+func updateGolden() bool {
+	if UpdateGolden {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		update, _ := strconv.ParseBool(f.Value.String())
+		return update
+	}
+	return false
+}
+
+func assertSQL(t testing.TB, query sq.Query, update bool) {
+	t.Helper()
+	filename := filepath.Join("testdata", goldenName(t)+".golden")
+	got := goldenSQL(query)
+	if update {
+		err := os.MkdirAll("testdata", 0755)
+		if err == nil {
+			err = ioutil.WriteFile(filename, got, 0644)
+		}
+		if err != nil {
+			t.Fatalf("sqtest: %s", err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("sqtest: %s (run the tests with -update to create it)", err)
+		return
+	}
+	want = bytes.Replace(want, []byte("\r\n"), []byte("\n"), -1)
+	if !bytes.Equal(want, got) {
+		t.Errorf("sqtest: query does not match %s (run the tests with -update to update it)\n--- want\n%s+++ got\n%s", filename, want, got)
+	}
+}
+
+var goldenNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// goldenName returns the name of the next golden file for a test, which is the
+// test name with any characters that are not safe in a filename replaced.
+func goldenName(t testing.TB) string {
+	name := goldenNameRegexp.ReplaceAllString(t.Name(), "_")
+	goldenMu.Lock()
+	defer goldenMu.Unlock()
+	goldenCalls[t]++
+	if n := goldenCalls[t]; n > 1 {
+		name += "_" + strconv.Itoa(n)
+	}
+	return name
+}
+
+// goldenSQL returns the contents of the golden file for a query.
+func goldenSQL(query sq.Query) []byte {
+	var s string
+	var args []interface{}
+	if q, ok := query.(interface {
+		ToSQLPretty(indent string) (string, []interface{})
+	}); ok {
+		s, args = q.ToSQLPretty("    ")
+	} else {
+		s, args = query.ToSQL()
+	}
+	buf := &strings.Builder{}
+	buf.WriteString(s)
+	buf.WriteString("\n")
+	for i, arg := range args {
+		value, err := sq.Interpolate("?", []interface{}{arg})
+		if err != nil {
+			value = fmt.Sprintf("%#v", arg)
+		}
+		buf.WriteString("-- " + strconv.Itoa(i+1) + " = " + value + "\n")
+	}
+	return []byte(buf.String())
+}
@@ -0,0 +1,80 @@
+package sqtest
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	sq "github.com/bokwoon95/go-structured-query/mysql"
+	"github.com/matryer/is"
+)
+
+// run the tests with -update to update the golden files in testdata
+var _ = flag.Bool("update", false, "update the golden files")
+
+type goldenTestT struct {
+	testing.TB
+	name   string
+	errors []string
+}
+
+func (t *goldenTestT) Helper() {}
+
+func (t *goldenTestT) Name() string { return t.name }
+
+func (t *goldenTestT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *goldenTestT) Fatalf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertSQL(t *testing.T) {
+	u := USERS().As("u")
+	t.Run("select", func(t *testing.T) {
+		AssertSQL(t, sq.From(u).
+			Where(u.NAME.EqString("bob"), sq.Or(u.EMAIL.IsNull(), u.USER_ID.In([]int{1, 2}))).
+			OrderBy(u.USER_ID).
+			Select(u.USER_ID, u.NAME))
+		AssertSQL(t, sq.DeleteFrom(u).Where(u.USER_ID.EqInt(1)))
+	})
+	t.Run("union", func(t *testing.T) {
+		AssertSQL(t, sq.Union(
+			sq.From(u).Where(u.USER_ID.EqInt(1)).Select(u.USER_ID),
+			sq.From(u).Where(u.USER_ID.EqInt(2)).Select(u.USER_ID),
+		))
+	})
+}
+
+func TestAssertSQL_Fail(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+
+	// query differs from the golden file
+	fakeT := &goldenTestT{name: "TestAssertSQL_Fail/mismatch"}
+	assertSQL(fakeT, sq.From(u).Where(u.NAME.EqString("alice")).Select(u.USER_ID), false)
+	is.Equal(1, len(fakeT.errors))
+
+	// query matches the golden file
+	fakeT = &goldenTestT{name: "TestAssertSQL_Fail/mismatch"}
+	assertSQL(fakeT, sq.From(u).Where(u.NAME.EqString("bob")).Select(u.USER_ID), false)
+	is.Equal(0, len(fakeT.errors))
+
+	// golden file does not exist
+	fakeT = &goldenTestT{name: "TestAssertSQL_Fail/missing"}
+	assertSQL(fakeT, sq.From(u).Select(u.USER_ID), false)
+	is.Equal(1, len(fakeT.errors))
+}
+
+func Test_updateGolden(t *testing.T) {
+	is := is.New(t)
+	is.Equal(false, updateGolden())
+	UpdateGolden = true
+	is.Equal(true, updateGolden())
+	UpdateGolden = false
+	is.NoErr(flag.Set("update", "true"))
+	is.Equal(true, updateGolden())
+	is.NoErr(flag.Set("update", "false"))
+	is.Equal(false, updateGolden())
+}
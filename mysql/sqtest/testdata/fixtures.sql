@@ -0,0 +1,10 @@
+-- Fixtures are committed and run again by every test binary, so they must be idempotent.
+DROP TABLE IF EXISTS sqtest_users;
+
+CREATE TABLE sqtest_users (
+    user_id INT PRIMARY KEY
+    ,name VARCHAR(255)
+    ,email VARCHAR(255)
+);
+
+INSERT INTO sqtest_users (user_id, name, email) VALUES (1, 'bob', 'bob@email.com');
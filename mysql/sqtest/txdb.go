@@ -0,0 +1,156 @@
+package sqtest
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DATA-DOG/go-txdb"
+)
+
+var (
+	txdbMu       sync.Mutex
+	txdbDrivers  = make(map[string]string)
+	txdbFixtures = make(map[string]*fixtures)
+	txdbConnID   int64
+)
+
+// fixtures are the fixture files that have been loaded into a database.
+type fixtures struct {
+	once sync.Once
+	err  error
+}
+
+// NewTxDB opens a database connection whose every query runs inside a single
+// transaction, which is rolled back when the test and all its subtests have
+// finished. Every call opens a separate transaction, so parallel tests that
+// each call NewTxDB do not see each other's changes. driverName and dsn are
+// the same as what would be passed to sql.Open, e.g. "mysql" and
+// "user:password@tcp(127.0.0.1:3306)/dbname?parseTime=true", and the driver
+// must have been registered by importing it.
+//
+// NewTxDB runs and commits the fixture SQL files (such as a schema and its
+// seed data) before opening the connection. Each set of fixtures is only
+// loaded once per test binary, so that the fixtures are the starting state of
+// every test in the package. The statements of a fixture file are run one at
+// a time, see splitStatements.
+//
+// The fixtures are committed, so they are run again against the same
+// database by every later test binary. They must be idempotent, e.g. drop
+// and recreate their tables or use CREATE TABLE IF NOT EXISTS and INSERT
+// IGNORE.
+//
+// NewTxDB fails the test if the fixtures cannot be loaded or the database
+// cannot be reached.
+func NewTxDB(t testing.TB, driverName, dsn string, fixtureFiles ...string) *sql.DB {
+	t.Helper()
+	err := loadFixtures(driverName, dsn, fixtureFiles)
+	if err != nil {
+		t.Fatalf("sqtest: %s", err)
+	}
+	id := atomic.AddInt64(&txdbConnID, 1)
+	db, err := sql.Open(txdbDriver(driverName, dsn), t.Name()+"#"+strconv.FormatInt(id, 10))
+	if err != nil {
+		t.Fatalf("sqtest: %s", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	err = db.Ping()
+	if err != nil {
+		t.Fatalf("sqtest: %s", err)
+	}
+	return db
+}
+
+// txdbDriver returns the name of the txdb driver for the driverName and dsn,
+// registering it if it has not been registered yet.
+func txdbDriver(driverName, dsn string) string {
+	key := driverName + " " + dsn
+	txdbMu.Lock()
+	defer txdbMu.Unlock()
+	name, ok := txdbDrivers[key]
+	if !ok {
+		name = "sqtest_txdb_mysql_" + strconv.Itoa(len(txdbDrivers))
+		txdb.Register(name, driverName, dsn)
+		txdbDrivers[key] = name
+	}
+	return name
+}
+
+// loadFixtures runs the fixture files against the database at dsn, unless
+// they have already been loaded.
+func loadFixtures(driverName, dsn string, fixtureFiles []string) error {
+	if len(fixtureFiles) == 0 {
+		return nil
+	}
+	key := driverName + " " + dsn + " " + strings.Join(fixtureFiles, " ")
+	txdbMu.Lock()
+	f, ok := txdbFixtures[key]
+	if !ok {
+		f = &fixtures{}
+		txdbFixtures[key] = f
+	}
+	txdbMu.Unlock()
+	f.once.Do(func() {
+		f.err = execFixtures(driverName, dsn, fixtureFiles)
+	})
+	return f.err
+}
+
+func execFixtures(driverName, dsn string, fixtureFiles []string) error {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	for _, filename := range fixtureFiles {
+		b, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		for _, statement := range splitStatements(string(b)) {
+			_, err = db.Exec(statement)
+			if err != nil {
+				return fmt.Errorf("loading fixture %s: %w", filename, err)
+			}
+		}
+	}
+	return nil
+}
+
+// splitStatements splits the contents of an SQL file into its statements. Like
+// the mysql client, a statement ends at a line ending with the delimiter, which
+// is a semicolon ; unless it has been changed with a DELIMITER line (for
+// creating stored procedures).
+func splitStatements(s string) []string {
+	var statements []string
+	delimiter := ";"
+	buf := &strings.Builder{}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if len(line) > len("DELIMITER ") && strings.EqualFold(line[:len("DELIMITER ")], "DELIMITER ") {
+			delimiter = strings.TrimSpace(line[len("DELIMITER "):])
+			continue
+		}
+		if !strings.HasSuffix(line, delimiter) {
+			buf.WriteString(line)
+			buf.WriteString("\n")
+			continue
+		}
+		buf.WriteString(strings.TrimSuffix(line, delimiter))
+		if statement := strings.TrimSpace(buf.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		buf.Reset()
+	}
+	if statement := strings.TrimSpace(buf.String()); statement != "" {
+		statements = append(statements, statement)
+	}
+	return statements
+}
@@ -0,0 +1,125 @@
+package sqtest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	sq "github.com/bokwoon95/go-structured-query/mysql"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/joho/godotenv"
+	"github.com/matryer/is"
+)
+
+func TestNewTxDB(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	err := godotenv.Load("../../.env")
+	if err != nil {
+		t.Fatal("Error loading .env file")
+	}
+	dsn := fmt.Sprintf("%s:%s@tcp(127.0.0.1:%s)/%s?parseTime=true", os.Getenv("MYSQL_USER"), os.Getenv("MYSQL_PASSWORD"), os.Getenv("MYSQL_PORT"), os.Getenv("MYSQL_NAME"))
+	u := USERS()
+	u.TableInfo.Schema, u.TableInfo.Name = "", "sqtest_users"
+	for _, newName := range []string{"alice", "eve"} {
+		newName := newName
+		t.Run(newName, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			db := NewTxDB(t, "mysql", dsn, "testdata/fixtures.sql")
+			_, _, err := sq.InsertInto(u).Columns(u.USER_ID, u.NAME).Values(2, newName).Exec(db, 0)
+			is.NoErr(err)
+			// each test only sees the fixtures and its own changes
+			var name string
+			var names []string
+			err = sq.From(u).OrderBy(u.USER_ID).Selectx(func(row *sq.Row) {
+				name = row.String(u.NAME)
+			}, func() {
+				names = append(names, name)
+			}).Fetch(db)
+			is.NoErr(err)
+			is.Equal([]string{"bob", newName}, names)
+		})
+	}
+}
+
+type fixturesTestDriver struct {
+	db *DB
+}
+
+func (d fixturesTestDriver) Open(name string) (driver.Conn, error) {
+	return conn{db: d.db}, nil
+}
+
+func Test_loadFixtures(t *testing.T) {
+	is := is.New(t)
+	db := NewDB()
+	db.StubRegexp(`sqtest_users`)
+	sql.Register("sqtest_fixtures_test", fixturesTestDriver{db: db})
+	b, err := ioutil.ReadFile("testdata/fixtures.sql")
+	is.NoErr(err)
+
+	// fixtures are only loaded once
+	for i := 0; i < 2; i++ {
+		err = loadFixtures("sqtest_fixtures_test", "dsn", []string{"testdata/fixtures.sql"})
+		is.NoErr(err)
+	}
+	statements := splitStatements(string(b))
+	is.Equal(3, len(statements))
+	is.Equal(3, len(db.Calls()))
+	is.Equal(statements[0], db.Calls()[0].Query)
+
+	// fixtures are loaded once per database
+	err = loadFixtures("sqtest_fixtures_test", "another dsn", []string{"testdata/fixtures.sql"})
+	is.NoErr(err)
+	is.Equal(6, len(db.Calls()))
+
+	// missing fixture file
+	err = loadFixtures("sqtest_fixtures_test", "dsn", []string{"testdata/missing.sql"})
+	is.True(err != nil)
+}
+
+func Test_splitStatements(t *testing.T) {
+	type TT struct {
+		description    string
+		s              string
+		wantStatements []string
+	}
+	tests := []TT{
+		{
+			"empty",
+			"\n  \n",
+			nil,
+		},
+		{
+			"statements",
+			"SET FOREIGN_KEY_CHECKS=0;\r\n\r\n-- users\nCREATE TABLE users (\n    user_id INT\n);\nSELECT 1",
+			[]string{
+				"SET FOREIGN_KEY_CHECKS=0",
+				"-- users\nCREATE TABLE users (\n    user_id INT\n)",
+				"SELECT 1",
+			},
+		},
+		{
+			"delimiter",
+			"DROP PROCEDURE IF EXISTS p;\nDELIMITER $$\nCREATE PROCEDURE p() BEGIN\n    SELECT 1;\nEND $$\ndelimiter ;\nCALL p();",
+			[]string{
+				"DROP PROCEDURE IF EXISTS p",
+				"CREATE PROCEDURE p() BEGIN\n    SELECT 1;\nEND",
+				"CALL p()",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.wantStatements, splitStatements(tt.s))
+		})
+	}
+}
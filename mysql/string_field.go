@@ -14,14 +14,23 @@ func NewEnumField(name string, table Table) EnumField {
 type StringField struct {
 	// StringField will be one of the following:
 
-	// 1) Literal string value
+	// 1) String expression
+	// Examples of string expressions:
+	// | query              | args |
+	// |--------------------|------|
+	// | LOWER(users.email) |      |
+	// | BIN_TO_UUID(?)     | uuid |
+	format *string
+	values []interface{}
+
+	// 2) Literal string value
 	// Examples of literal string values:
 	// | query | args |
 	// |-------|------|
 	// | ?     | abcd |
 	value *string
 
-	// 2) String column
+	// 3) String column
 	// Examples of boolean columns:
 	// | query       | args |
 	// |-------------|------|
@@ -39,12 +48,15 @@ type StringField struct {
 // excludedTableQualifiers list.
 func (f StringField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
 	switch {
+	case f.format != nil:
+		// 1) String expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal string value
+		// 2) Literal string value
 		buf.WriteString("?")
 		*args = append(*args, *f.value)
 	default:
-		// 2) String column
+		// 3) String column
 		tableQualifier := f.table.GetAlias()
 		if tableQualifier == "" {
 			tableQualifier = f.table.GetName()
@@ -280,6 +292,27 @@ func (f StringField) In(v interface{}) Predicate {
 	}
 }
 
+// NotIn returns an 'X NOT IN (Y)' Predicate.
+func (f StringField) NotIn(v interface{}) Predicate {
+	var format string
+	var values []interface{}
+	switch v := v.(type) {
+	case RowValue:
+		format = "? NOT IN ?"
+		values = []interface{}{f, v}
+	case Query:
+		format = "? NOT IN (?)"
+		values = []interface{}{f, v.NestThis()}
+	default:
+		format = "? NOT IN (?)"
+		values = []interface{}{f, v}
+	}
+	return CustomPredicate{
+		Format: format,
+		Values: values,
+	}
+}
+
 // String returns the string representation of the StringField.
 func (f StringField) String() string {
 	buf := &strings.Builder{}
@@ -297,3 +330,11 @@ func (f StringField) GetAlias() string {
 func (f StringField) GetName() string {
 	return f.name
 }
+
+// StringFieldf creates a new string expression.
+func StringFieldf(format string, values ...interface{}) StringField {
+	return StringField{
+		format: &format,
+		values: values,
+	}
+}
@@ -0,0 +1,125 @@
+package sq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Concat represents the CONCAT() function, which concatenates the StringField
+// with each value (a field or a Go string). NULL values are ignored.
+func (f StringField) Concat(values ...interface{}) StringField {
+	format := "CONCAT(?" + strings.Repeat(", ?", len(values)) + ")"
+	return StringFieldf(format, append([]interface{}{f}, values...)...)
+}
+
+// Lower represents the LOWER() function.
+func (f StringField) Lower() StringField {
+	return StringFieldf("LOWER(?)", f)
+}
+
+// Upper represents the UPPER() function.
+func (f StringField) Upper() StringField {
+	return StringFieldf("UPPER(?)", f)
+}
+
+// Trim represents the TRIM() function, which removes leading and trailing
+// whitespace.
+func (f StringField) Trim() StringField {
+	return StringFieldf("TRIM(?)", f)
+}
+
+// Substring represents the SUBSTRING(X, start, length) function. The start is
+// 1-indexed. If length is negative the rest of the string is taken i.e.
+// 'SUBSTRING(X, start)'.
+func (f StringField) Substring(start, length int) StringField {
+	if length < 0 {
+		return StringFieldf("SUBSTRING(?, ?)", f, start)
+	}
+	return StringFieldf("SUBSTRING(?, ?, ?)", f, start, length)
+}
+
+// Replace represents the REPLACE() function, which replaces every occurrence
+// of from with to. Both may be a field or a Go string.
+func (f StringField) Replace(from, to interface{}) StringField {
+	return StringFieldf("REPLACE(?, ?, ?)", f, from, to)
+}
+
+// Left represents the LEFT() function, which returns the first n characters.
+func (f StringField) Left(n int) StringField {
+	return StringFieldf("LEFT(?, ?)", f, n)
+}
+
+// Right represents the RIGHT() function, which returns the last n characters.
+func (f StringField) Right(n int) StringField {
+	return StringFieldf("RIGHT(?, ?)", f, n)
+}
+
+// LPad represents the LPAD() function, which left pads the StringField to the
+// length with fill (a field or a Go string).
+func (f StringField) LPad(length int, fill interface{}) StringField {
+	return StringFieldf("LPAD(?, ?, ?)", f, length, fill)
+}
+
+// RPad represents the RPAD() function, which right pads the StringField to the
+// length with fill (a field or a Go string).
+func (f StringField) RPad(length int, fill interface{}) StringField {
+	return StringFieldf("RPAD(?, ?, ?)", f, length, fill)
+}
+
+// Length represents the CHAR_LENGTH() function, which returns the number of
+// characters (not bytes) in the StringField.
+func (f StringField) Length() NumberField {
+	format := "CHAR_LENGTH(?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{f},
+	}
+}
+
+// Position represents the POSITION(substring IN X) function, which returns the
+// 1-indexed location of the substring (0 if it is not present).
+func (f StringField) Position(substring interface{}) NumberField {
+	format := "POSITION(? IN ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{substring, f},
+	}
+}
+
+// Regexp returns an 'X REGEXP pattern' Predicate.
+func (f StringField) Regexp(pattern interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? REGEXP ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// NotRegexp returns an 'X NOT REGEXP pattern' Predicate.
+func (f StringField) NotRegexp(pattern interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? NOT REGEXP ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// RegexpReplace represents the REGEXP_REPLACE() function, which replaces
+// every match of the pattern with the replacement.
+func (f StringField) RegexpReplace(pattern, replacement interface{}) StringField {
+	return StringFieldf("REGEXP_REPLACE(?, ?, ?)", f, pattern, replacement)
+}
+
+// Collate returns a new StringField with the collation applied i.e. 'X
+// COLLATE name', which affects both comparison and ordering. It panics if the
+// collation name is not made up of letters, digits and underscores, because
+// the name cannot be passed as an argument.
+func (f StringField) Collate(name string) StringField {
+	if name == "" {
+		panic(fmt.Errorf("sq: invalid collation %q", name))
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			panic(fmt.Errorf("sq: invalid collation %q", name))
+		}
+	}
+	return StringFieldf("? COLLATE "+name, f)
+}
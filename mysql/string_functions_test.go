@@ -0,0 +1,65 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestStringFunctions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	tests := []TT{
+		{"Concat", u.DISPLAYNAME.Concat(" <", u.EMAIL, ">"), nil, "CONCAT(u.displayname, ?, u.email, ?)", []interface{}{" <", ">"}},
+		{"Lower", u.EMAIL.Lower(), []string{"u"}, "LOWER(email)", nil},
+		{"Upper Trim", u.EMAIL.Trim().Upper(), nil, "UPPER(TRIM(u.email))", nil},
+		{"Substring", u.EMAIL.Substring(2, 3), nil, "SUBSTRING(u.email, ?, ?)", []interface{}{2, 3}},
+		{"Substring to the end", u.EMAIL.Substring(2, -1), nil, "SUBSTRING(u.email, ?)", []interface{}{2}},
+		{"Replace", u.EMAIL.Replace("@", u.DISPLAYNAME), nil, "REPLACE(u.email, ?, u.displayname)", []interface{}{"@"}},
+		{"Left", u.EMAIL.Left(3), nil, "LEFT(u.email, ?)", []interface{}{3}},
+		{"Right", u.EMAIL.Right(3), nil, "RIGHT(u.email, ?)", []interface{}{3}},
+		{"LPad", u.DISPLAYNAME.LPad(10, "*"), nil, "LPAD(u.displayname, ?, ?)", []interface{}{10, "*"}},
+		{"RPad", u.DISPLAYNAME.RPad(10, u.EMAIL), nil, "RPAD(u.displayname, ?, u.email)", []interface{}{10}},
+		{"Length", u.EMAIL.Length(), nil, "CHAR_LENGTH(u.email)", nil},
+		{"Position", u.EMAIL.Position("@"), nil, "POSITION(? IN u.email)", []interface{}{"@"}},
+		{"RegexpReplace", u.EMAIL.RegexpReplace("[0-9]+", "#"), nil, "REGEXP_REPLACE(u.email, ?, ?)", []interface{}{"[0-9]+", "#"}},
+		{"Collate", u.DISPLAYNAME.Collate("utf8mb4_bin").Desc(), nil, "u.displayname COLLATE utf8mb4_bin DESC", nil},
+		{"Regexp", u.EMAIL.Regexp("^a"), nil, "u.email REGEXP ?", []interface{}{"^a"}},
+		{"NotRegexp", u.EMAIL.NotRegexp(u.DISPLAYNAME), nil, "u.email NOT REGEXP u.displayname", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestStringFunctions_Queries(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	gotQuery, gotArgs := From(u).
+		Where(u.EMAIL.Lower().EqString("bob@example.com")).
+		OrderBy(u.DISPLAYNAME.Collate("utf8mb4_bin")).
+		Select(u.DISPLAYNAME.Upper().As("name"), u.EMAIL.Length()).
+		ToSQL()
+	is.Equal("SELECT UPPER(u.displayname) AS name, CHAR_LENGTH(u.email) FROM devlab.users AS u"+
+		" WHERE LOWER(u.email) = ? ORDER BY u.displayname COLLATE utf8mb4_bin", gotQuery)
+	is.Equal([]interface{}{"bob@example.com"}, gotArgs)
+
+	defer func() { is.True(recover() != nil) }()
+	u.DISPLAYNAME.Collate("utf8mb4_bin; DROP TABLE users")
+}
@@ -2,8 +2,6 @@ package sq
 
 import (
 	"database/sql"
-	"database/sql/driver"
-	"encoding/json"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -33,8 +31,12 @@ func expandValues(buf *strings.Builder, args *[]interface{}, excludedTableQualif
 
 // appendSQLValue will write the SQL representation of the interface{} value
 // into the buffer and args slice. It propagates excludedTableQualifiers where
-// relevant.
+// relevant. Lists of values are collapsed into a single placeholder if the
+// query is being rendered by Fingerprint.
 func appendSQLValue(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string, value interface{}) {
+	if fingerprinting(args) && appendCollapsedList(buf, args, value) {
+		return
+	}
 	switch v := value.(type) {
 	case nil:
 		buf.WriteString("NULL")
@@ -97,63 +99,19 @@ func randomString(n int) string {
 }
 
 // interpolateSQLValue interpolates an interface value as its SQL
-// representation into a buffer. This makes it vulnerable to SQL injection and
-// should be used for display purposes ONLY, not for actually running against a
-// database.
+// representation into a buffer for display in logs. It escapes the value the
+// same way as Interpolate, but falls back to printing the value with
+// fmt.Sprintf if it cannot be represented in SQL.
 func interpolateSQLValue(buf *strings.Builder, value interface{}) {
-	switch v := value.(type) {
-	case nil:
-		buf.WriteString("NULL")
-	case bool:
-		if v {
-			buf.WriteString("TRUE")
-		} else {
-			buf.WriteString("FALSE")
-		}
-	case string:
-		buf.WriteString("'")
-		buf.WriteString(v)
-		buf.WriteString("'")
-	case int, int8, int16, int32, int64,
-		uint, uint8, uint16, uint32, uint64,
-		float32, float64:
-		buf.WriteString(fmt.Sprint(value))
-	case time.Time:
-		buf.WriteString("'")
-		buf.WriteString(v.Format(time.RFC3339Nano))
-		buf.WriteString("'")
-	case driver.Valuer:
-		Interface, err := v.Value()
-		if err != nil {
-			buf.WriteString(":")
-			buf.WriteString(err.Error())
-			buf.WriteString(":")
-		} else {
-			switch Concrete := Interface.(type) {
-			case string:
-				buf.WriteString("'")
-				buf.WriteString(Concrete)
-				buf.WriteString("'")
-			case nil:
-				buf.WriteString("NULL")
-			default:
-				buf.WriteString(":")
-				buf.WriteString(fmt.Sprintf("%#v", value)) // give up, don't know what it is, resort to fmt.Sprintf
-				buf.WriteString(":")
-			}
-		}
-	default:
-		b, err := json.Marshal(value)
-		if err != nil {
-			buf.WriteString(":")
-			buf.WriteString(fmt.Sprintf("%#v", value)) // give up, don't know what it is, resort to fmt.Sprintf
-			buf.WriteString(":")
-		} else {
-			buf.WriteString("'")
-			buf.Write(b)
-			buf.WriteString("'")
-		}
+	tmpbuf := &strings.Builder{}
+	err := interpolateValue(tmpbuf, time.UTC, value)
+	if err != nil {
+		buf.WriteString(":")
+		buf.WriteString(fmt.Sprintf("%#v", value)) // give up, don't know what it is, resort to fmt.Sprintf
+		buf.WriteString(":")
+		return
 	}
+	buf.WriteString(tmpbuf.String())
 }
 
 // appendSQLDisplay marshals an interface value into a buffer.
@@ -201,9 +159,8 @@ func appendSQLDisplay(buf *strings.Builder, value interface{}) {
 }
 
 // questionInterpolate interpolates the question mark ? placeholders in a query
-// string with the args in the args slice. It is vulnerable to SQL injection
-// and should be used for display purposes only, not for actually running
-// against a database.
+// string with the args in the args slice. It is meant for display purposes,
+// use Interpolate to get SQL that can be run against a database.
 func questionInterpolate(query string, args ...interface{}) string {
 	buf := &strings.Builder{}
 	// i is the position of the ? in the query
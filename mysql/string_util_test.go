@@ -44,7 +44,7 @@ func TestInterpolateSQLValue(t *testing.T) {
 		func() TT {
 			desc := "time"
 			now := time.Now()
-			return TT{desc, now, "'" + now.Format(time.RFC3339Nano) + "'"}
+			return TT{desc, now, "'" + now.UTC().Format("2006-01-02 15:04:05.999999") + "'"}
 		}(),
 		{"driver.Valuer value", valuer{a: 3, b: 4}, "'7'"},
 		{"driver.Valuer nil", valuer{a: 0, b: 0}, "NULL"},
@@ -5,18 +5,28 @@ import (
 	"time"
 )
 
-// TimeField either represents a time column or a literal time.Time value.
+// TimeField either represents a time column, a time expression or a literal
+// time.Time value.
 type TimeField struct {
 	// TimeField will be one of the following:
 
-	// 1) Literal time.Time value
+	// 1) Time expression
+	// Examples of time expressions:
+	// | query                                    | args |
+	// |------------------------------------------|------|
+	// | NOW()                                    |      |
+	// | DATE_ADD(users.joined, INTERVAL ? MONTH) | 1    |
+	format *string
+	values []interface{}
+
+	// 2) Literal time.Time value
 	// Examples of literal string values:
 	// | query | args       |
 	// |-------|------------|
 	// | ?     | time.Now() |
 	value *time.Time
 
-	// 2) Time column
+	// 3) Time column
 	// Examples of time columns:
 	// | query            | args |
 	// |------------------|------|
@@ -33,12 +43,15 @@ type TimeField struct {
 // in the TimeField internal struct comments.
 func (f TimeField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
 	switch {
+	case f.format != nil:
+		// 1) Time expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal time.Time value
+		// 2) Literal time.Time value
 		buf.WriteString("?")
 		*args = append(*args, *f.value)
 	default:
-		// 2) Time column
+		// 3) Time column
 		tableQualifier := f.table.GetAlias()
 		if tableQualifier == "" {
 			tableQualifier = f.table.GetName()
@@ -295,3 +308,11 @@ func (f TimeField) GetAlias() string {
 func (f TimeField) GetName() string {
 	return f.name
 }
+
+// TimeFieldf creates a new time expression.
+func TimeFieldf(format string, values ...interface{}) TimeField {
+	return TimeField{
+		format: &format,
+		values: values,
+	}
+}
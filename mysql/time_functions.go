@@ -0,0 +1,177 @@
+package sq
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Now represents the NOW() function.
+func Now() TimeField {
+	format := "NOW()"
+	return TimeField{
+		format: &format,
+	}
+}
+
+// CurrentDate represents the CURRENT_DATE function.
+func CurrentDate() TimeField {
+	format := "CURRENT_DATE"
+	return TimeField{
+		format: &format,
+	}
+}
+
+// Interval represents a span of time made up of calendar units (years, months
+// and days, whose lengths vary) followed by an exact time.Duration.
+type Interval struct {
+	Years    int
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// dateAdd nests one DATE_ADD() or DATE_SUB() call for each non-zero unit of
+// the Interval, because a MySQL INTERVAL can only have a single unit. A zero
+// Interval returns the TimeField unchanged.
+func dateAdd(function string, f TimeField, interval Interval) TimeField {
+	type unit struct {
+		name  string
+		value int64
+	}
+	units := []unit{
+		{"YEAR", int64(interval.Years)},
+		{"MONTH", int64(interval.Months)},
+		{"DAY", int64(interval.Days)},
+		{"MICROSECOND", interval.Duration.Microseconds()},
+	}
+	result := f
+	for _, u := range units {
+		if u.value == 0 {
+			continue
+		}
+		format := function + "(?, INTERVAL ? " + u.name + ")"
+		result = TimeField{
+			format: &format,
+			values: []interface{}{result, u.value},
+		}
+	}
+	return result
+}
+
+// AddInterval returns a new TimeField with the Interval added to it i.e.
+// 'DATE_ADD(X, INTERVAL n unit)'.
+func (f TimeField) AddInterval(interval Interval) TimeField {
+	return dateAdd("DATE_ADD", f, interval)
+}
+
+// SubInterval returns a new TimeField with the Interval subtracted from it i.e.
+// 'DATE_SUB(X, INTERVAL n unit)'.
+func (f TimeField) SubInterval(interval Interval) TimeField {
+	return dateAdd("DATE_SUB", f, interval)
+}
+
+// AddDuration returns a new TimeField with the time.Duration added to it.
+func (f TimeField) AddDuration(d time.Duration) TimeField {
+	return f.AddInterval(Interval{Duration: d})
+}
+
+// SubDuration returns a new TimeField with the time.Duration subtracted from
+// it.
+func (f TimeField) SubDuration(d time.Duration) TimeField {
+	return f.SubInterval(Interval{Duration: d})
+}
+
+// DateTrunc truncates the TimeField to the precision of the unit (year,
+// quarter, month, week, day, hour, minute or second), like Postgres'
+// date_trunc(). Weeks start on Monday. It panics if the unit is not supported.
+func (f TimeField) DateTrunc(unit string) TimeField {
+	var format string
+	var values []interface{}
+	switch strings.ToLower(unit) {
+	case "year":
+		format, values = "CAST(DATE_FORMAT(?, '%Y-01-01') AS DATETIME)", []interface{}{f}
+	case "quarter":
+		format, values = "CAST(MAKEDATE(YEAR(?), 1) + INTERVAL (QUARTER(?) - 1) QUARTER AS DATETIME)", []interface{}{f, f}
+	case "month":
+		format, values = "CAST(DATE_FORMAT(?, '%Y-%m-01') AS DATETIME)", []interface{}{f}
+	case "week":
+		format, values = "CAST(DATE_SUB(DATE(?), INTERVAL WEEKDAY(?) DAY) AS DATETIME)", []interface{}{f, f}
+	case "day":
+		format, values = "CAST(DATE(?) AS DATETIME)", []interface{}{f}
+	case "hour":
+		format, values = "CAST(DATE_FORMAT(?, '%Y-%m-%d %H:00:00') AS DATETIME)", []interface{}{f}
+	case "minute":
+		format, values = "CAST(DATE_FORMAT(?, '%Y-%m-%d %H:%i:00') AS DATETIME)", []interface{}{f}
+	case "second":
+		format, values = "CAST(DATE_FORMAT(?, '%Y-%m-%d %H:%i:%s') AS DATETIME)", []interface{}{f}
+	default:
+		panic(fmt.Errorf("sq: DateTrunc does not support the unit %q", unit))
+	}
+	return TimeField{
+		format: &format,
+		values: values,
+	}
+}
+
+// Extract represents the EXTRACT(part FROM X) function e.g. Extract("year")
+// or Extract("year_month"). It panics if the part is not a valid identifier,
+// because the part cannot be passed as an argument.
+func (f TimeField) Extract(part string) NumberField {
+	format := "EXTRACT(" + timePartKeyword(part) + " FROM ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{f},
+	}
+}
+
+// AtTimeZone returns a new TimeField converted from the session time zone to
+// the time zone i.e. 'CONVERT_TZ(X, @@session.time_zone, tz)'.
+func (f TimeField) AtTimeZone(tz string) TimeField {
+	format := "CONVERT_TZ(?, @@session.time_zone, ?)"
+	return TimeField{
+		format: &format,
+		values: []interface{}{f, tz},
+	}
+}
+
+// DateDiff represents the TIMESTAMPDIFF(unit, start, end) function, which
+// returns the number of whole units (year, quarter, month, week, day, hour,
+// minute or second) between start and end. Like the Postgres DateDiff, the
+// unit is case insensitive and may be plural e.g. "days". It panics if the
+// unit is not supported. MySQL has no equivalent of Postgres' age(), so
+// DateDiff is also what to use in its place.
+func DateDiff(unit string, start, end TimeField) NumberField {
+	keyword, ok := map[string]string{
+		"year": "YEAR", "years": "YEAR",
+		"quarter": "QUARTER", "quarters": "QUARTER",
+		"month": "MONTH", "months": "MONTH",
+		"week": "WEEK", "weeks": "WEEK",
+		"day": "DAY", "days": "DAY",
+		"hour": "HOUR", "hours": "HOUR",
+		"minute": "MINUTE", "minutes": "MINUTE",
+		"second": "SECOND", "seconds": "SECOND",
+	}[strings.ToLower(unit)]
+	if !ok {
+		panic(fmt.Errorf("sq: DateDiff does not support the unit %q", unit))
+	}
+	format := "TIMESTAMPDIFF(" + keyword + ", ?, ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{start, end},
+	}
+}
+
+// timePartKeyword returns the part as an uppercase keyword, panicking if it
+// contains anything other than letters and underscores.
+func timePartKeyword(part string) string {
+	if part == "" {
+		panic(fmt.Errorf("sq: empty time part"))
+	}
+	for _, c := range part {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_') {
+			panic(fmt.Errorf("sq: invalid time part %q", part))
+		}
+	}
+	return strings.ToUpper(part)
+}
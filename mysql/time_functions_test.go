@@ -0,0 +1,134 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestTimeFunctions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	s := SESSIONS().As("s")
+	tests := []TT{
+		{"Now", Now(), nil, "NOW()", nil},
+		{"CurrentDate", CurrentDate(), nil, "CURRENT_DATE", nil},
+		{
+			"AddInterval",
+			s.CREATED_AT.AddInterval(Interval{Years: 1, Months: 2, Days: 3, Duration: 90 * time.Minute}),
+			nil,
+			"DATE_ADD(DATE_ADD(DATE_ADD(DATE_ADD(s.created_at, INTERVAL ? YEAR), INTERVAL ? MONTH), INTERVAL ? DAY), INTERVAL ? MICROSECOND)",
+			[]interface{}{int64(1), int64(2), int64(3), int64(5400000000)},
+		},
+		{
+			"SubInterval",
+			Now().SubInterval(Interval{Months: 1}),
+			nil,
+			"DATE_SUB(NOW(), INTERVAL ? MONTH)",
+			[]interface{}{int64(1)},
+		},
+		{
+			"AddDuration",
+			s.CREATED_AT.AddDuration(1500 * time.Millisecond),
+			[]string{"s"},
+			"DATE_ADD(created_at, INTERVAL ? MICROSECOND)",
+			[]interface{}{int64(1500000)},
+		},
+		{
+			"SubDuration zero",
+			s.CREATED_AT.SubDuration(0),
+			nil,
+			"s.created_at",
+			nil,
+		},
+		{
+			"DateTrunc month",
+			s.CREATED_AT.DateTrunc("month"),
+			nil,
+			"CAST(DATE_FORMAT(s.created_at, '%Y-%m-01') AS DATETIME)",
+			nil,
+		},
+		{
+			"DateTrunc week",
+			s.CREATED_AT.DateTrunc("WEEK"),
+			nil,
+			"CAST(DATE_SUB(DATE(s.created_at), INTERVAL WEEKDAY(s.created_at) DAY) AS DATETIME)",
+			nil,
+		},
+		{
+			"DateTrunc quarter",
+			s.CREATED_AT.DateTrunc("quarter"),
+			nil,
+			"CAST(MAKEDATE(YEAR(s.created_at), 1) + INTERVAL (QUARTER(s.created_at) - 1) QUARTER AS DATETIME)",
+			nil,
+		},
+		{
+			"Extract",
+			s.CREATED_AT.Extract("year_month"),
+			nil,
+			"EXTRACT(YEAR_MONTH FROM s.created_at)",
+			nil,
+		},
+		{
+			"AtTimeZone",
+			s.CREATED_AT.AtTimeZone("+08:00"),
+			nil,
+			"CONVERT_TZ(s.created_at, @@session.time_zone, ?)",
+			[]interface{}{"+08:00"},
+		},
+		{
+			"DateDiff",
+			DateDiff("day", s.CREATED_AT, Now()),
+			nil,
+			"TIMESTAMPDIFF(DAY, s.created_at, NOW())",
+			nil,
+		},
+		{
+			"DateDiff plural",
+			DateDiff("Days", s.CREATED_AT, Now()),
+			nil,
+			"TIMESTAMPDIFF(DAY, s.created_at, NOW())",
+			nil,
+		},
+		{
+			"DateDiff quarters",
+			DateDiff("quarters", s.CREATED_AT, Now()),
+			nil,
+			"TIMESTAMPDIFF(QUARTER, s.created_at, NOW())",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestTimeFunctions_Panics(t *testing.T) {
+	is := is.New(t)
+	s := SESSIONS()
+	mustPanic := func(fn func()) {
+		defer func() { is.True(recover() != nil) }()
+		fn()
+	}
+	mustPanic(func() { s.CREATED_AT.Extract("year FROM NOW()); DROP TABLE users; --") })
+	mustPanic(func() { s.CREATED_AT.DateTrunc("decade") })
+	mustPanic(func() { DateDiff("", s.CREATED_AT, Now()) })
+	mustPanic(func() { DateDiff("decade", s.CREATED_AT, Now()) })
+	mustPanic(func() { DateDiff("microsecond", s.CREATED_AT, Now()) })
+}
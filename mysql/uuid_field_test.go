@@ -0,0 +1,169 @@
+package sq
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type testUUID [16]byte
+
+var testUUIDValue = [16]byte{0x5e, 0x0e, 0x1d, 0x3a, 0x8e, 0x2b, 0x4a, 0xc4, 0x9f, 0x3e, 0x2b, 0x1c, 0x4d, 0x6f, 0x7a, 0x80}
+
+const testUUIDString = "5e0e1d3a-8e2b-4ac4-9f3e-2b1c4d6f7a80"
+
+func TestUUIDField_AppendSQLExclude(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			desc := "literal value"
+			f := UUID(testUUIDValue)
+			wantQuery := "?"
+			wantArgs := []interface{}{testUUIDValue[:]}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "GenerateUUID"
+			f := GenerateUUID()
+			wantQuery := "UUID_TO_BIN(UUID())"
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "UUIDToBin"
+			f := UUIDToBin(testUUIDString)
+			wantQuery := "UUID_TO_BIN(?)"
+			wantArgs := []interface{}{testUUIDString}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "BinToUUID"
+			f := NewUUIDField("uuid", &TableInfo{Schema: "devlab", Name: "media"}).As("u").BinToUUID()
+			wantQuery := "BIN_TO_UUID(media.uuid)"
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "excludedTableQualifiers (alias)"
+			f := NewUUIDField("uuid", &TableInfo{Schema: "devlab", Name: "media", Alias: "m"})
+			exclude := []string{"m"}
+			wantQuery := "uuid"
+			return TT{desc, f, exclude, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "quoted whitespace Desc"
+			f := NewUUIDField("media uuid", &TableInfo{Schema: "devlab", Name: "all media"}).Desc()
+			wantQuery := "`all media`.`media uuid` DESC"
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUUIDField_Predicates(t *testing.T) {
+	type TT struct {
+		description string
+		p           Predicate
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	m := MEDIA().As("m")
+	tests := []TT{
+		{"IsNull", m.UUID.IsNull(), nil, "m.uuid IS NULL", nil},
+		{"IsNotNull", m.UUID.IsNotNull(), nil, "m.uuid IS NOT NULL", nil},
+		{"Eq field", m.UUID.Eq(m.UUID), []string{"m"}, "uuid = uuid", nil},
+		{"Eq [16]byte", m.UUID.Eq(testUUIDValue), nil, "m.uuid = ?", []interface{}{testUUIDValue[:]}},
+		{"Eq named [16]byte type", m.UUID.Eq(testUUID(testUUIDValue)), nil, "m.uuid = ?", []interface{}{testUUIDValue[:]}},
+		{"Eq string", m.UUID.Eq(testUUIDString), nil, "m.uuid = UUID_TO_BIN(?)", []interface{}{testUUIDString}},
+		{"Ne [16]byte", m.UUID.Ne(testUUIDValue), nil, "m.uuid <> ?", []interface{}{testUUIDValue[:]}},
+		{
+			"In slice",
+			m.UUID.In([]interface{}{testUUIDValue, testUUIDString}),
+			nil,
+			"m.uuid IN (?, UUID_TO_BIN(?))",
+			[]interface{}{testUUIDValue[:], testUUIDString},
+		},
+		{"In empty slice", m.UUID.In([][16]byte{}), nil, "m.uuid IN (NULL)", nil},
+		{
+			"NotIn slice",
+			m.UUID.NotIn([]interface{}{testUUIDValue, testUUIDString}),
+			nil,
+			"m.uuid NOT IN (?, UUID_TO_BIN(?))",
+			[]interface{}{testUUIDValue[:], testUUIDString},
+		},
+		{"NotIn empty slice", m.UUID.NotIn([][16]byte{}), nil, "m.uuid NOT IN (NULL)", nil},
+		{"NotIn RowValue", m.UUID.NotIn(RowValue{m.UUID}), nil, "m.uuid NOT IN (m.uuid)", nil},
+		{
+			"NotIn subquery",
+			m.UUID.NotIn(Select(m.UUID).From(m)),
+			nil,
+			"m.uuid NOT IN (SELECT m.uuid FROM devlab.media AS m)",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.p.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUUIDField_Set(t *testing.T) {
+	is := is.New(t)
+	m := MEDIA()
+	gotQuery, gotArgs := InsertInto(m).Valuesx(func(col *Column) {
+		col.SetUUID(m.UUID, testUUIDValue)
+		col.SetString(m.NAME, "a.png")
+	}).ToSQL()
+	is.Equal("INSERT INTO devlab.media (uuid, name) VALUES (?, ?)", gotQuery)
+	is.Equal([]interface{}{testUUIDValue[:], "a.png"}, gotArgs)
+
+	gotQuery, gotArgs = Update(m).Set(m.UUID.Set(GenerateUUID())).Where(m.UUID.Eq(testUUIDString)).ToSQL()
+	is.Equal("UPDATE devlab.media SET media.uuid = UUID_TO_BIN(UUID()) WHERE media.uuid = UUID_TO_BIN(?)", gotQuery)
+	is.Equal([]interface{}{testUUIDString}, gotArgs)
+}
+
+func TestRow_UUID(t *testing.T) {
+	is := is.New(t)
+	m := MEDIA()
+	mapper := func(row *Row) ([16]byte, [16]byte, bool) {
+		return row.UUID(m.UUID), row.UUID(m.UUID), row.UUIDValid(m.UUID)
+	}
+	r := &Row{}
+	mapper(r)
+	is.Equal(3, len(r.fields))
+	// Populate the destinations as if they had been scanned.
+	r.jsonValues = make([]json.RawMessage, len(r.fields))
+	*r.dest[0].(*sql.NullString) = sql.NullString{String: string(testUUIDValue[:]), Valid: true}
+	*r.dest[1].(*sql.NullString) = sql.NullString{String: testUUIDString, Valid: true}
+	raw, text, valid := mapper(r)
+	is.Equal(testUUIDValue, raw)
+	is.Equal(testUUIDValue, text)
+	is.True(!valid)
+}
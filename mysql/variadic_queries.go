@@ -1,9 +1,15 @@
 package sq
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // VariadicQueryOperator is an operator that can join a variadic number of
@@ -25,12 +31,22 @@ const (
 type VariadicQuery struct {
 	nested   bool
 	topLevel bool
+	format   sqlFormat
 	Operator VariadicQueryOperator
 	Queries  []Query
+	// ORDER BY
+	OrderByFields Fields
+	// LIMIT
+	LimitValue *int64
+	// OFFSET
+	OffsetValue *int64
 	// DB
 	DB          DB
 	Mapper      func(*Row)
 	Accumulator func()
+	// AutoValidate makes Fetch and Exec call Validate before running the
+	// query.
+	AutoValidate bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -60,46 +76,81 @@ func (vq VariadicQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, par
 			buf.WriteString("NULL")
 		case VariadicQuery:
 			q.topLevel = true
-			q.NestThis().AppendSQL(buf, args, nil)
+			vq.format.nest(q).AppendSQL(buf, args, nil)
 		default:
-			q.NestThis().AppendSQL(buf, args, nil)
+			vq.format.nest(q).AppendSQL(buf, args, nil)
 		}
 	default:
 		if !vq.topLevel {
 			buf.WriteString("(")
+			vq.format = vq.format.indented()
+			if vq.format.pretty {
+				vq.format.newline(buf)
+			}
 		}
 		for i, q := range vq.Queries {
 			if i > 0 {
-				buf.WriteString(" ")
+				vq.format.newline(buf)
 				buf.WriteString(string(vq.Operator))
-				buf.WriteString(" ")
+				vq.format.newline(buf)
 			}
 			switch q := q.(type) {
 			case nil:
 				buf.WriteString("NULL")
 			case VariadicQuery:
 				q.topLevel = false
-				q.NestThis().AppendSQL(buf, args, nil)
+				vq.format.nest(q).AppendSQL(buf, args, nil)
 			default:
-				q.NestThis().AppendSQL(buf, args, nil)
+				vq.format.nest(q).AppendSQL(buf, args, nil)
 			}
 		}
-		if !vq.topLevel {
-			buf.WriteString(")")
+	}
+	// ORDER BY
+	if len(vq.OrderByFields) > 0 {
+		vq.format.newline(buf)
+		buf.WriteString("ORDER BY ")
+		appendVariadicOrderBy(buf, args, vq.OrderByFields, variadicTableQualifiers(vq.Queries))
+	}
+	// LIMIT
+	if vq.LimitValue != nil {
+		vq.format.newline(buf)
+		buf.WriteString("LIMIT ?")
+		if *vq.LimitValue < 0 {
+			*vq.LimitValue = -*vq.LimitValue
+		}
+		*args = append(*args, *vq.LimitValue)
+	}
+	// OFFSET
+	if vq.OffsetValue != nil {
+		vq.format.newline(buf)
+		buf.WriteString("OFFSET ?")
+		if *vq.OffsetValue < 0 {
+			*vq.OffsetValue = -*vq.OffsetValue
 		}
+		*args = append(*args, *vq.OffsetValue)
+	}
+	if len(vq.Queries) > 1 && !vq.topLevel {
+		if vq.format.pretty {
+			vq.format.depth--
+			vq.format.newline(buf)
+		}
+		buf.WriteString(")")
 	}
 	if !vq.nested {
 		if vq.Log != nil {
-			query := buf.String()
+			query, logArgs := buf.String(), *args
+			if Lpretty&vq.LogFlag != 0 && !vq.format.pretty {
+				query, logArgs = prettyLogQuery(vq)
+			}
 			var logOutput string
 			switch {
 			case Lstats&vq.LogFlag != 0:
-				logOutput = "\n----[ Executing query ]----\n" + buf.String() + " " + fmt.Sprint(*args) +
-					"\n----[ with bind values ]----\n" + questionInterpolate(query, *args...)
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(logArgs) +
+					"\n----[ with bind values ]----\n" + questionInterpolate(query, logArgs...)
 			case Linterpolate&vq.LogFlag != 0:
-				logOutput = questionInterpolate(query, *args...)
+				logOutput = questionInterpolate(query, logArgs...)
 			default:
-				logOutput = buf.String() + " " + fmt.Sprint(*args)
+				logOutput = query + " " + fmt.Sprint(logArgs)
 			}
 			switch vq.Log.(type) {
 			case *log.Logger:
@@ -111,6 +162,262 @@ func (vq VariadicQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, par
 	}
 }
 
+// appendVariadicOrderBy marshals the ORDER BY fields of a VariadicQuery into a
+// buffer and args slice. The result of a VariadicQuery can only be ordered by
+// its output columns, so an aliased field is written as its alias and any
+// other field is written without its table qualifier.
+func appendVariadicOrderBy(buf *strings.Builder, args *[]interface{}, fields Fields, excludedTableQualifiers []string) {
+	tmpbuf := &strings.Builder{}
+	var tmpargs []interface{}
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if field == nil {
+			buf.WriteString("NULL")
+			continue
+		}
+		tmpbuf.Reset()
+		tmpargs = tmpargs[:0]
+		field.AppendSQLExclude(tmpbuf, &tmpargs, nil, excludedTableQualifiers)
+		alias := field.GetAlias()
+		if alias == "" {
+			buf.WriteString(tmpbuf.String())
+			*args = append(*args, tmpargs...)
+			continue
+		}
+		buf.WriteString(alias)
+		// Keep the field's ordering, which is always rendered last.
+		query := tmpbuf.String()
+		var suffix string
+		for _, s := range []string{" NULLS FIRST", " NULLS LAST"} {
+			if strings.HasSuffix(query, s) {
+				query, suffix = strings.TrimSuffix(query, s), s
+				break
+			}
+		}
+		for _, s := range []string{" ASC", " DESC"} {
+			if strings.HasSuffix(query, s) {
+				suffix = s + suffix
+				break
+			}
+		}
+		buf.WriteString(suffix)
+	}
+}
+
+// variadicTableQualifiers returns the table qualifiers used by the FROM and
+// JOIN clauses of the queries, recursing into any nested VariadicQuery.
+func variadicTableQualifiers(queries []Query) []string {
+	var qualifiers []string
+	for _, query := range queries {
+		switch q := query.(type) {
+		case SelectQuery:
+			if q.FromTable != nil {
+				qualifiers = append(qualifiers, getAliasOrName(q.FromTable))
+			}
+			for _, joinTable := range q.JoinTables {
+				if joinTable.Table != nil {
+					qualifiers = append(qualifiers, getAliasOrName(joinTable.Table))
+				}
+			}
+		case VariadicQuery:
+			qualifiers = append(qualifiers, variadicTableQualifiers(q.Queries)...)
+		}
+	}
+	return qualifiers
+}
+
+// OrderBy appends the fields to the ORDER BY clause in the VariadicQuery.
+// Fields are resolved by their alias if they have one, otherwise by their
+// name.
+func (vq VariadicQuery) OrderBy(fields ...Field) VariadicQuery {
+	vq.OrderByFields = append(vq.OrderByFields, fields...)
+	return vq
+}
+
+// Limit sets the limit in the VariadicQuery.
+func (vq VariadicQuery) Limit(limit int) VariadicQuery {
+	num := int64(limit)
+	vq.LimitValue = &num
+	return vq
+}
+
+// Offset sets the offset in the VariadicQuery.
+func (vq VariadicQuery) Offset(offset int) VariadicQuery {
+	num := int64(offset)
+	vq.OffsetValue = &num
+	return vq
+}
+
+// Selectx sets the mapper function and accumulator function in the
+// VariadicQuery. Each SelectQuery in the VariadicQuery that has no fields of
+// its own selects the fields of the mapper function.
+func (vq VariadicQuery) Selectx(mapper func(*Row), accumulator func()) VariadicQuery {
+	vq.Mapper = mapper
+	vq.Accumulator = accumulator
+	return vq
+}
+
+// SelectRowx sets the mapper function in the VariadicQuery.
+func (vq VariadicQuery) SelectRowx(mapper func(*Row)) VariadicQuery {
+	vq.Mapper = mapper
+	return vq
+}
+
+// withSelectFields returns a copy of the queries where every SelectQuery
+// without any SelectFields selects the fields instead.
+func withSelectFields(queries []Query, fields Fields) []Query {
+	newQueries := make([]Query, len(queries))
+	for i, query := range queries {
+		switch q := query.(type) {
+		case SelectQuery:
+			if len(q.SelectFields) == 0 {
+				q.SelectFields = fields
+			}
+			newQueries[i] = q
+		case VariadicQuery:
+			q.Queries = withSelectFields(q.Queries, fields)
+			newQueries[i] = q
+		default:
+			newQueries[i] = query
+		}
+	}
+	return newQueries
+}
+
+// Fetch will run VariadicQuery with the given DB. It then maps the results
+// based on the mapper function (and optionally runs the accumulator function).
+func (vq VariadicQuery) Fetch(db DB) (err error) {
+	vq.logSkip += 1
+	return vq.FetchContext(nil, db)
+}
+
+// FetchContext will run VariadicQuery with the given DB and context. It then
+// maps the results based on the mapper function (and optionally runs the
+// accumulator function).
+func (vq VariadicQuery) FetchContext(ctx context.Context, db DB) (err error) {
+	if db == nil {
+		if vq.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = vq.DB
+	}
+	if vq.Mapper == nil {
+		return fmt.Errorf("cannot call Fetch/FetchContext without a mapper")
+	}
+	logBuf := &strings.Builder{}
+	start := time.Now()
+	var rowcount int
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case ExitCode:
+				if v != ExitPeacefully {
+					err = v
+				}
+			case error:
+				err = v
+			default:
+				err = fmt.Errorf("%#v", r)
+			}
+			return
+		}
+		if vq.Log == nil {
+			return
+		}
+		elapsed := time.Since(start)
+		logQueryStats(vq.Log, vq, int64(rowcount), elapsed, err)
+		if Lresults&vq.LogFlag != 0 && rowcount > 5 {
+			logBuf.WriteString("\n...")
+		}
+		if Lstats&vq.LogFlag != 0 {
+			logBuf.WriteString("\n(Fetched ")
+			logBuf.WriteString(strconv.Itoa(rowcount))
+			logBuf.WriteString(" rows in ")
+			logBuf.WriteString(elapsed.String())
+			logBuf.WriteString(")")
+		}
+		if logBuf.Len() > 0 {
+			switch vq.Log.(type) {
+			case *log.Logger:
+				_ = vq.Log.Output(vq.logSkip+2, logBuf.String())
+			default:
+				_ = vq.Log.Output(vq.logSkip+1, logBuf.String())
+			}
+		}
+	}()
+	r := &Row{}
+	vq.Mapper(r)
+	vq.Queries = withSelectFields(vq.Queries, r.fields)
+	if vq.AutoValidate {
+		if err = vq.Validate(); err != nil {
+			return err
+		}
+	}
+	tmpbuf := &strings.Builder{}
+	var tmpargs []interface{}
+	vq.logSkip += 1
+	vq.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, vq)
+	if ctx == nil {
+		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
+	} else {
+		r.rows, err = db.QueryContext(ctx, tmpbuf.String(), tmpargs...)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.rows.Close()
+	if len(r.dest) == 0 {
+		return nil
+	}
+	for r.rows.Next() {
+		rowcount++
+		err = r.rows.Scan(r.dest...)
+		if err != nil {
+			errbuf := &strings.Builder{}
+			for i := range r.dest {
+				tmpbuf.Reset()
+				tmpargs = tmpargs[:0]
+				r.fields[i].AppendSQLExclude(tmpbuf, &tmpargs, nil, nil)
+				errbuf.WriteString("\n" +
+					strconv.Itoa(i) + ") " +
+					questionInterpolate(tmpbuf.String(), tmpargs...) + " => " +
+					reflect.TypeOf(r.dest[i]).String())
+			}
+			return fmt.Errorf("Please check if your mapper function is correct:%s\n%w", errbuf.String(), err)
+		}
+		if vq.Log != nil && Lresults&vq.LogFlag != 0 && rowcount <= 5 {
+			logBuf.WriteString("\n----[ Row ")
+			logBuf.WriteString(strconv.Itoa(rowcount))
+			logBuf.WriteString(" ]----")
+			for i := range r.dest {
+				tmpbuf.Reset()
+				tmpargs = tmpargs[:0]
+				r.fields[i].AppendSQLExclude(tmpbuf, &tmpargs, nil, nil)
+				logBuf.WriteString("\n")
+				logBuf.WriteString(questionInterpolate(tmpbuf.String(), tmpargs...))
+				logBuf.WriteString(": ")
+				appendSQLDisplay(logBuf, r.dest[i])
+			}
+		}
+		r.index = 0
+		vq.Mapper(r)
+		if vq.Accumulator == nil {
+			break
+		}
+		vq.Accumulator()
+	}
+	if rowcount == 0 && vq.Accumulator == nil {
+		return sql.ErrNoRows
+	}
+	if e := r.rows.Close(); e != nil {
+		return e
+	}
+	return r.rows.Err()
+}
+
 // NestThis indicates to the VariadicQuery that it is nested.
 func (vq VariadicQuery) NestThis() Query {
 	vq.nested = true
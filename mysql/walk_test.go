@@ -0,0 +1,205 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// walkTables returns the names of every Table reported by Walk.
+func walkTables(node interface{}) []string {
+	var tables []string
+	Walk(node, func(node interface{}) bool {
+		switch node := node.(type) {
+		case Field:
+		case Table:
+			tables = append(tables, node.GetName()+" "+node.GetAlias())
+		}
+		return true
+	})
+	return tables
+}
+
+// walkLiterals returns the value of every Literal reported by Walk.
+func walkLiterals(node interface{}) []interface{} {
+	var literals []interface{}
+	Walk(node, func(node interface{}) bool {
+		if literal, ok := node.(Literal); ok {
+			literals = append(literals, literal.Value)
+		}
+		return true
+	})
+	return literals
+}
+
+func TestWalk(t *testing.T) {
+	type TT struct {
+		description  string
+		node         interface{}
+		wantTables   []string
+		wantLiterals []interface{}
+	}
+	u, ur, s := USERS().As("u"), USER_ROLES().As("ur"), SESSIONS().As("s")
+	m := MEDIA().As("m")
+	tests := []TT{
+		{
+			"uuid in list",
+			From(m).Where(m.UUID.In([][16]byte{{1}, {2}})).Select(m.UUID),
+			[]string{"media m"},
+			[]interface{}{[16]byte{1}, [16]byte{2}},
+		},
+		{
+			"select",
+			From(u).
+				Join(ur, ur.USER_ID.Eq(u.USER_ID)).
+				Where(
+					u.EMAIL.LikeString("%@gmail.com"),
+					Exists(From(s).Where(s.USER_ID.Eq(u.USER_ID), s.HASH.EqString("abc")).SelectOne()),
+				).
+				OrderBy(u.USER_ID).
+				Limit(10).
+				Select(u.USER_ID, ur.ROLE, Int(1).As("one")),
+			[]string{"users u", "user_roles ur", "sessions s"},
+			[]interface{}{1, "%@gmail.com", "abc", int64(10)},
+		},
+		{
+			"select with CTE and subquery",
+			func() Query {
+				cte := From(ur).Where(ur.ROLE.EqString("admin")).Select(ur.USER_ID).CTE("admins")
+				subq := From(s).GroupBy(s.USER_ID).Select(s.USER_ID, Count().As("sessions")).Subquery("subq")
+				return From(u).
+					Join(cte, cte.NumberField("user_id").Eq(u.USER_ID)).
+					LeftJoin(subq, subq.NumberField("user_id").Eq(u.USER_ID)).
+					Select(u.USER_ID, subq.NumberField("sessions"))
+			}(),
+			[]string{"users u", "admins ", "user_roles ur", " subq", "sessions s"},
+			[]interface{}{"admin"},
+		},
+		{
+			"recursive CTE",
+			func() Query {
+				tens := RecursiveCTE("tens", "n")
+				n := tens.NumberField("n")
+				tens = tens.
+					Initial(Select(Int(10))).
+					UnionAll(Select(n.Add(10)).From(tens).Where(n.LtInt(100)))
+				return Select(n).From(tens)
+			}(),
+			[]string{"tens ", "tens "},
+			[]interface{}{10, 10, 100},
+		},
+		{
+			"insert",
+			InsertInto(u).
+				Columns(u.USER_ID, u.EMAIL).
+				Values(1, "bob@email.com").
+				OnDuplicateKeyUpdate(u.EMAIL.Set(Values(u.EMAIL))),
+			[]string{"users u"},
+			[]interface{}{1, "bob@email.com"},
+		},
+		{
+			"insert select",
+			InsertInto(u).
+				Columns(u.USER_ID).
+				Select(From(ur).Where(ur.ROLE.In([]string{"a", "b"})).Select(ur.USER_ID)),
+			[]string{"users u", "user_roles ur"},
+			[]interface{}{"a", "b"},
+		},
+		{
+			"update",
+			Update(u).
+				Join(ur, ur.USER_ID.Eq(u.USER_ID)).
+				Set(u.EMAIL.SetString("bob@email.com")).
+				Where(ur.ROLE.In([]string{"a"})).
+				OrderBy(u.USER_ID).
+				Limit(1),
+			[]string{"users u", "user_roles ur"},
+			[]interface{}{"bob@email.com", "a", int64(1)},
+		},
+		{
+			"delete",
+			DeleteFrom(u, ur).
+				Using(u).
+				Join(ur, ur.USER_ID.Eq(u.USER_ID)).
+				Where(ur.COHORT.EqString("2020")),
+			[]string{"users u", "user_roles ur", "users u", "user_roles ur"},
+			[]interface{}{"2020"},
+		},
+		{
+			"union",
+			Union(
+				From(u).Where(u.USER_ID.EqInt(1)).Select(u.USER_ID),
+				From(ur).Where(ur.USER_ID.EqInt(2)).Select(ur.USER_ID),
+			),
+			[]string{"users u", "user_roles ur"},
+			[]interface{}{1, 2},
+		},
+		{
+			"match",
+			From(MEDIA().As("m")).Where(Match(MEDIA().As("m").NAME).Against("cat")).SelectOne(),
+			[]string{"media m"},
+			[]interface{}{"cat"},
+		},
+		{
+			"predicate",
+			Or(u.USER_ID.In(RowValue{1, 2}), Eq(CaseWhen(u.EMAIL.IsNull(), "none").Else(u.EMAIL), "x")),
+			[]string{},
+			[]interface{}{1, 2, "none", "x"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			tables := walkTables(tt.node)
+			if tables == nil {
+				tables = []string{}
+			}
+			is.Equal(tt.wantTables, tables)
+			is.Equal(tt.wantLiterals, walkLiterals(tt.node))
+		})
+	}
+}
+
+func TestWalk_SkipChildren(t *testing.T) {
+	is := is.New(t)
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	q := From(u).
+		Where(Exists(From(ur).Where(ur.USER_ID.Eq(u.USER_ID)).SelectOne())).
+		Select(u.USER_ID)
+	var tables []string
+	Walk(q, func(node interface{}) bool {
+		switch node := node.(type) {
+		case CustomPredicate:
+			return false // skip the EXISTS subquery
+		case Field:
+		case Table:
+			tables = append(tables, node.GetName())
+		}
+		return true
+	})
+	is.Equal([]string{"users"}, tables)
+}
+
+func TestWalk_Mutations(t *testing.T) {
+	is := is.New(t)
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	// writtenTable returns the first table reported after the query itself.
+	writtenTable := func(q Query) string {
+		var name string
+		Walk(q, func(node interface{}) bool {
+			if _, ok := node.(Field); ok {
+				return true
+			}
+			if table, ok := node.(Table); ok && name == "" {
+				name = table.GetName()
+			}
+			return name == ""
+		})
+		return name
+	}
+	is.Equal("users", writtenTable(InsertInto(u).Columns(u.USER_ID).Values(1)))
+	is.Equal("users", writtenTable(Update(u).Join(ur, ur.USER_ID.Eq(u.USER_ID)).Set(u.USER_ID.SetInt(1))))
+	is.Equal("users", writtenTable(DeleteFrom(u).Join(ur, ur.USER_ID.Eq(u.USER_ID))))
+}
@@ -83,7 +83,8 @@ func (w Window) OrderBy(fields ...Field) Window {
 }
 
 // Frame sets the frame definition of the window e.g. RANGE BETWEEN 5 PRECEDING
-// AND 10 FOLLOWING.
+// AND 10 FOLLOWING. WithFrame builds and validates the frame definition from a
+// WindowFrame instead.
 func (w Window) Frame(frameDefinition string) Window {
 	w.FrameDefinition = frameDefinition
 	return w
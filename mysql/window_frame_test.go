@@ -0,0 +1,76 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestWindowFrame(t *testing.T) {
+	type TT struct {
+		description string
+		frame       WindowFrame
+		wantFrame   string
+	}
+	tests := []TT{
+		{"default", RowsFrame(), "ROWS UNBOUNDED PRECEDING"},
+		{"Start", RowsFrame().Start(Preceding(3)), "ROWS 3 PRECEDING"},
+		{"Between", RowsFrame().Between(UnboundedPreceding(), CurrentRow()), "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW"},
+		{"Following", RowsFrame().Between(CurrentRow(), Following(int64(2))), "ROWS BETWEEN CURRENT ROW AND 2 FOLLOWING"},
+		{"UnboundedFollowing", RangeFrame().Between(Preceding(5), UnboundedFollowing()), "RANGE BETWEEN 5 PRECEDING AND UNBOUNDED FOLLOWING"},
+		{
+			"Range interval",
+			RangeFrame().Between(Preceding(Interval{Days: 7}), Following(time.Second)),
+			"RANGE BETWEEN INTERVAL 7 DAY PRECEDING AND INTERVAL 1000000 MICROSECOND FOLLOWING",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.wantFrame, tt.frame.String())
+		})
+	}
+}
+
+func TestWindowFrame_Panics(t *testing.T) {
+	tests := map[string]func(){
+		"start at UNBOUNDED FOLLOWING": func() { RowsFrame().Start(UnboundedFollowing()) },
+		"end at UNBOUNDED PRECEDING":   func() { RowsFrame().Between(CurrentRow(), UnboundedPreceding()) },
+		"interval offset in ROWS mode": func() { RowsFrame().Between(Preceding(time.Hour), CurrentRow()) },
+		"multi unit interval offset":   func() { RangeFrame().Start(Preceding(Interval{Days: 1, Duration: time.Hour})) },
+		"negative offset":              func() { RowsFrame().Start(Preceding(-1)) },
+		"unsupported offset":           func() { Preceding("1; DROP TABLE users") },
+	}
+	for description, f := range tests {
+		f := f
+		t.Run(description, func(t *testing.T) {
+			is := is.New(t)
+			defer func() { is.True(recover() != nil) }()
+			f()
+		})
+	}
+}
+
+func TestWindowFrame_Over(t *testing.T) {
+	is := is.New(t)
+	ur := USER_ROLES().As("ur")
+	w := PartitionBy(ur.COHORT).OrderBy(ur.USER_ID).WithFrame(RowsFrame().Between(Preceding(2), CurrentRow()))
+	buf := &strings.Builder{}
+	var args []interface{}
+	Fields{
+		BoolAndOver(ur.DELETED_AT.IsNull(), w),
+		BoolOrOver(ur.DELETED_AT.IsNull(), w),
+		StddevOver(ur.USER_ID, w),
+		VarianceOver(ur.USER_ID, w).Filter(ur.DELETED_AT.IsNull()),
+	}.AppendSQLExclude(buf, &args, nil, nil)
+	window := "(PARTITION BY ur.cohort ORDER BY ur.user_id ROWS BETWEEN 2 PRECEDING AND CURRENT ROW)"
+	is.Equal("MIN(ur.deleted_at IS NULL) OVER "+window+
+		", MAX(ur.deleted_at IS NULL) OVER "+window+
+		", STDDEV_SAMP(ur.user_id) OVER "+window+
+		", VAR_SAMP(CASE WHEN ur.deleted_at IS NULL THEN ur.user_id END) OVER "+window, buf.String())
+	is.Equal(0, len(args))
+}
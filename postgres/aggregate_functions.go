@@ -1,5 +1,10 @@
 package sq
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Count represents the COUNT(*) aggregate function.
 func Count() NumberField {
 	format := "COUNT(*)"
@@ -88,3 +93,241 @@ func MaxOver(field interface{}, window Window) NumberField {
 		values: []interface{}{field, window},
 	}
 }
+
+// CountDistinct represents the COUNT(DISTINCT) aggregate function.
+func CountDistinct(field interface{}) NumberField {
+	format := "COUNT(DISTINCT ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field},
+	}
+}
+
+// StringAgg represents the STRING_AGG() aggregate function, which concatenates
+// the values separated by the separator. If orderBy fields are provided, the
+// values are concatenated in that order i.e. 'STRING_AGG(X, sep ORDER BY Y)'.
+func StringAgg(field interface{}, separator string, orderBy ...Field) StringField {
+	format := "STRING_AGG(?, ?)"
+	values := []interface{}{field, separator}
+	if len(orderBy) > 0 {
+		format = "STRING_AGG(?, ? ORDER BY ?)"
+		values = append(values, Fields(orderBy))
+	}
+	return StringField{
+		format: &format,
+		values: values,
+	}
+}
+
+// ArrayAgg represents the ARRAY_AGG() aggregate function, which collects the
+// values into an array. If orderBy fields are provided, the values are
+// collected in that order i.e. 'ARRAY_AGG(X ORDER BY Y)'.
+func ArrayAgg(field interface{}, orderBy ...Field) ArrayField {
+	format := "ARRAY_AGG(?)"
+	values := []interface{}{field}
+	if len(orderBy) > 0 {
+		format = "ARRAY_AGG(? ORDER BY ?)"
+		values = append(values, Fields(orderBy))
+	}
+	return ArrayField{
+		format: &format,
+		values: values,
+	}
+}
+
+// BoolAnd represents the BOOL_AND() aggregate function, which is true if the
+// predicate is true for every row.
+func BoolAnd(predicate Predicate) BooleanField {
+	format := "BOOL_AND(?)"
+	return BooleanField{
+		format: &format,
+		values: []interface{}{predicate},
+	}
+}
+
+// BoolOr represents the BOOL_OR() aggregate function, which is true if the
+// predicate is true for any row.
+func BoolOr(predicate Predicate) BooleanField {
+	format := "BOOL_OR(?)"
+	return BooleanField{
+		format: &format,
+		values: []interface{}{predicate},
+	}
+}
+
+// Stddev represents the STDDEV() aggregate function, which returns the sample
+// standard deviation.
+func Stddev(field interface{}) NumberField {
+	format := "STDDEV(?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field},
+	}
+}
+
+// Variance represents the VARIANCE() aggregate function, which returns the
+// sample variance.
+func Variance(field interface{}) NumberField {
+	format := "VARIANCE(?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field},
+	}
+}
+
+// StringAggOver represents the STRING_AGG() OVER window function. Postgres
+// does not support ORDER BY inside a windowed aggregate, order the window
+// instead.
+func StringAggOver(field interface{}, separator string, window Window) StringField {
+	format := "STRING_AGG(?, ?) OVER ?"
+	return StringField{
+		format: &format,
+		values: []interface{}{field, separator, window},
+	}
+}
+
+// ArrayAggOver represents the ARRAY_AGG() OVER window function. Postgres does
+// not support ORDER BY inside a windowed aggregate, order the window instead.
+func ArrayAggOver(field interface{}, window Window) ArrayField {
+	format := "ARRAY_AGG(?) OVER ?"
+	return ArrayField{
+		format: &format,
+		values: []interface{}{field, window},
+	}
+}
+
+// BoolAndOver represents the BOOL_AND() OVER window function.
+func BoolAndOver(predicate Predicate, window Window) BooleanField {
+	format := "BOOL_AND(?) OVER ?"
+	return BooleanField{
+		format: &format,
+		values: []interface{}{predicate, window},
+	}
+}
+
+// BoolOrOver represents the BOOL_OR() OVER window function.
+func BoolOrOver(predicate Predicate, window Window) BooleanField {
+	format := "BOOL_OR(?) OVER ?"
+	return BooleanField{
+		format: &format,
+		values: []interface{}{predicate, window},
+	}
+}
+
+// StddevOver represents the STDDEV() OVER window function.
+func StddevOver(field interface{}, window Window) NumberField {
+	format := "STDDEV(?) OVER ?"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field, window},
+	}
+}
+
+// VarianceOver represents the VARIANCE() OVER window function.
+func VarianceOver(field interface{}, window Window) NumberField {
+	format := "VARIANCE(?) OVER ?"
+	return NumberField{
+		format: &format,
+		values: []interface{}{field, window},
+	}
+}
+
+// PercentileCont represents the PERCENTILE_CONT(fraction) WITHIN GROUP (ORDER
+// BY X) ordered-set aggregate function, which interpolates between values.
+func PercentileCont(fraction float64, orderBy Field) NumberField {
+	format := "PERCENTILE_CONT(?) WITHIN GROUP (ORDER BY ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{fraction, orderBy},
+	}
+}
+
+// PercentileDisc represents the PERCENTILE_DISC(fraction) WITHIN GROUP (ORDER
+// BY X) ordered-set aggregate function, which returns the first value whose
+// position is at or above the fraction. The result has the same type as the
+// orderBy field, use Row.ScanInto to read it.
+func PercentileDisc(fraction float64, orderBy Field) CustomField {
+	return CustomField{
+		Format: "PERCENTILE_DISC(?) WITHIN GROUP (ORDER BY ?)",
+		Values: []interface{}{fraction, orderBy},
+	}
+}
+
+// Mode represents the MODE() WITHIN GROUP (ORDER BY X) ordered-set aggregate
+// function, which returns the most frequent value. The result has the same
+// type as the orderBy field, use Row.ScanInto to read it.
+func Mode(orderBy Field) CustomField {
+	return CustomField{
+		Format: "MODE() WITHIN GROUP (ORDER BY ?)",
+		Values: []interface{}{orderBy},
+	}
+}
+
+// filterAggregate adds a 'FILTER (WHERE predicates)' clause to an aggregate
+// function's format and values. For window functions the clause is placed
+// before the OVER clause. It panics if format is nil i.e. the field is not an
+// aggregate function.
+func filterAggregate(format *string, values []interface{}, predicates []Predicate) (string, []interface{}) {
+	if format == nil {
+		panic(fmt.Errorf("sq: FILTER can only be applied to an aggregate function"))
+	}
+	filter := VariadicPredicate{
+		toplevel:   true,
+		Operator:   PredicateAnd,
+		Predicates: predicates,
+	}
+	newValues := make([]interface{}, 0, len(values)+1)
+	if strings.HasSuffix(*format, " OVER ?") && len(values) > 0 {
+		// SUM(?) FILTER (WHERE ?) OVER ?
+		newFormat := strings.TrimSuffix(*format, " OVER ?") + " FILTER (WHERE ?) OVER ?"
+		newValues = append(newValues, values[:len(values)-1]...)
+		newValues = append(newValues, filter, values[len(values)-1])
+		return newFormat, newValues
+	}
+	newValues = append(newValues, values...)
+	newValues = append(newValues, filter)
+	return *format + " FILTER (WHERE ?)", newValues
+}
+
+// Filter returns a new NumberField with a 'FILTER (WHERE predicates)' clause,
+// which restricts the rows fed into the aggregate function. It panics if the
+// NumberField is not an aggregate function.
+func (f NumberField) Filter(predicates ...Predicate) NumberField {
+	format, values := filterAggregate(f.format, f.values, predicates)
+	f.format, f.values = &format, values
+	return f
+}
+
+// Filter returns a new StringField with a 'FILTER (WHERE predicates)' clause,
+// which restricts the rows fed into the aggregate function. It panics if the
+// StringField is not an aggregate function.
+func (f StringField) Filter(predicates ...Predicate) StringField {
+	format, values := filterAggregate(f.format, f.values, predicates)
+	f.format, f.values = &format, values
+	return f
+}
+
+// Filter returns a new BooleanField with a 'FILTER (WHERE predicates)' clause,
+// which restricts the rows fed into the aggregate function. It panics if the
+// BooleanField is not an aggregate function.
+func (f BooleanField) Filter(predicates ...Predicate) BooleanField {
+	format, values := filterAggregate(f.format, f.values, predicates)
+	f.format, f.values = &format, values
+	return f
+}
+
+// Filter returns a new ArrayField with a 'FILTER (WHERE predicates)' clause,
+// which restricts the rows fed into the aggregate function. It panics if the
+// ArrayField is not an aggregate function.
+func (f ArrayField) Filter(predicates ...Predicate) ArrayField {
+	format, values := filterAggregate(f.format, f.values, predicates)
+	f.format, f.values = &format, values
+	return f
+}
+
+// Filter returns a new CustomField with a 'FILTER (WHERE predicates)' clause,
+// which restricts the rows fed into the aggregate function.
+func (f CustomField) Filter(predicates ...Predicate) CustomField {
+	f.Format, f.Values = filterAggregate(&f.Format, f.Values, predicates)
+	return f
+}
@@ -87,6 +87,118 @@ func TestAggregateFunctions(t *testing.T) {
 			"MAX(ur.user_role_id) OVER (PARTITION BY ur.user_id)",
 			nil,
 		},
+		{
+			"CountDistinct",
+			CountDistinct(ur.ROLE),
+			nil,
+			"COUNT(DISTINCT ur.role)",
+			nil,
+		},
+		{
+			"StringAgg",
+			StringAgg(ur.ROLE, ", "),
+			nil,
+			"STRING_AGG(ur.role, ?)",
+			[]interface{}{", "},
+		},
+		{
+			"StringAgg ORDER BY",
+			StringAgg(ur.ROLE, ", ", ur.CREATED_AT.Desc(), ur.ROLE),
+			nil,
+			"STRING_AGG(ur.role, ? ORDER BY ur.created_at DESC, ur.role)",
+			[]interface{}{", "},
+		},
+		{
+			"ArrayAgg ORDER BY",
+			ArrayAgg(ur.USER_ID, ur.USER_ID.Desc()),
+			[]string{"ur"},
+			"ARRAY_AGG(user_id ORDER BY user_id DESC)",
+			nil,
+		},
+		{
+			"BoolAnd",
+			BoolAnd(ur.DELETED_AT.IsNull()),
+			nil,
+			"BOOL_AND(ur.deleted_at IS NULL)",
+			nil,
+		},
+		{
+			"BoolOr",
+			BoolOr(ur.ROLE.EqString("admin")),
+			nil,
+			"BOOL_OR(ur.role = ?)",
+			[]interface{}{"admin"},
+		},
+		{
+			"Stddev",
+			Stddev(ur.USER_ID),
+			nil,
+			"STDDEV(ur.user_id)",
+			nil,
+		},
+		{
+			"Variance",
+			Variance(ur.USER_ID),
+			nil,
+			"VARIANCE(ur.user_id)",
+			nil,
+		},
+		{
+			"PercentileCont",
+			PercentileCont(0.5, ur.USER_ID),
+			nil,
+			"PERCENTILE_CONT(?) WITHIN GROUP (ORDER BY ur.user_id)",
+			[]interface{}{0.5},
+		},
+		{
+			"PercentileDisc",
+			PercentileDisc(0.9, ur.CREATED_AT.Desc()),
+			nil,
+			"PERCENTILE_DISC(?) WITHIN GROUP (ORDER BY ur.created_at DESC)",
+			[]interface{}{0.9},
+		},
+		{
+			"Mode Filter",
+			Mode(ur.ROLE).Filter(ur.DELETED_AT.IsNull()),
+			nil,
+			"MODE() WITHIN GROUP (ORDER BY ur.role) FILTER (WHERE ur.deleted_at IS NULL)",
+			nil,
+		},
+		{
+			"Count Filter",
+			Count().Filter(ur.ROLE.EqString("admin"), ur.DELETED_AT.IsNull()),
+			nil,
+			"COUNT(*) FILTER (WHERE ur.role = ? AND ur.deleted_at IS NULL)",
+			[]interface{}{"admin"},
+		},
+		{
+			"SumOver Filter",
+			SumOver(ur.USER_ID, PartitionBy(ur.ROLE)).Filter(ur.DELETED_AT.IsNull()),
+			nil,
+			"SUM(ur.user_id) FILTER (WHERE ur.deleted_at IS NULL) OVER (PARTITION BY ur.role)",
+			nil,
+		},
+		{
+			"StringAgg Filter",
+			StringAgg(ur.ROLE, ",").Filter(ur.USER_ID.GtInt(1)),
+			nil,
+			"STRING_AGG(ur.role, ?) FILTER (WHERE ur.user_id > ?)",
+			[]interface{}{",", 1},
+		},
+		{
+			"BoolAnd Filter",
+			BoolAnd(ur.DELETED_AT.IsNull()).Filter(ur.USER_ID.GtInt(1)),
+			nil,
+			"BOOL_AND(ur.deleted_at IS NULL) FILTER (WHERE ur.user_id > ?)",
+			[]interface{}{1},
+		},
+		{
+			"ArrayAgg Filter",
+			ArrayAgg(ur.ROLE).Filter(ur.USER_ID.GtInt(1)),
+			nil,
+			"ARRAY_AGG(ur.role) FILTER (WHERE ur.user_id > ?)",
+			[]interface{}{1},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -101,3 +213,10 @@ func TestAggregateFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateFunctions_FilterPanics(t *testing.T) {
+	is := is.New(t)
+	ur := USER_ROLES().As("ur")
+	defer func() { is.True(recover() != nil) }()
+	ur.USER_ID.Filter(ur.DELETED_AT.IsNull())
+}
@@ -5,11 +5,21 @@ import (
 	"strings"
 )
 
-// ArrayField either represents an ARRAY column, or a literal slice value.
+// ArrayField either represents an ARRAY column, an array expression or a
+// literal slice value.
 type ArrayField struct {
 	// ArrayField will be one of the following:
 
-	// 1) Literal slice value (only []bool, []float64, []int64 or []string
+	// 1) Array expression
+	// Examples of array expressions:
+	// | query                        | args |
+	// |------------------------------|------|
+	// | array_agg(users.email)       |      |
+	// | array_append(film.actors, ?) | bob  |
+	format *string
+	values []interface{}
+
+	// 2) Literal slice value (only []bool, []float64, []int64 or []string
 	// slices are supported.) Nested slices are also not supported even though
 	// both Go and Postgres support nested slices/arrays because I'm not even
 	// sure if it's possible to convert between the two with lib/pq.
@@ -24,8 +34,8 @@ type ArrayField struct {
 	// | ARRAY[?, ?, ?]    | apple, banana, cucumber |
 	value interface{}
 
-	// 2) Array column
-	// Examples of boolean columns:
+	// 3) Array column
+	// Examples of array columns:
 	// | query                 | args |
 	// |-----------------------|------|
 	// | film.special_features |      |
@@ -42,8 +52,11 @@ type ArrayField struct {
 // excludedTableQualifiers list.
 func (f ArrayField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
 	switch {
+	case f.format != nil:
+		// 1) Array expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal slice value
+		// 2) Literal slice value
 		switch array := f.value.(type) {
 		case []bool:
 			if len(array) == 0 {
@@ -104,7 +117,7 @@ func (f ArrayField) AppendSQLExclude(buf *strings.Builder, args *[]interface{},
 			buf.WriteString(fmt.Sprintf("(unsupported type %#v: only []bool/[]float64/[]int64/[]string/[]int slices are supported.)", f.value))
 		}
 	default:
-		// 2) Array column
+		// 3) Array column
 		tableQualifier := f.table.GetAlias()
 		if tableQualifier == "" {
 			tableQualifier = f.table.GetName()
@@ -330,3 +343,11 @@ func (f ArrayField) GetAlias() string {
 func (f ArrayField) GetName() string {
 	return f.name
 }
+
+// ArrayFieldf creates a new array expression.
+func ArrayFieldf(format string, values ...interface{}) ArrayField {
+	return ArrayField{
+		format: &format,
+		values: values,
+	}
+}
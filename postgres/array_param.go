@@ -0,0 +1,106 @@
+package sq
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// arrayParamThreshold is the number of elements above which a slice passed to
+// In or NotIn is automatically bound as a single array parameter.
+const arrayParamThreshold = 1000
+
+// ArrayParameter is a slice that is bound to the query as a single postgres
+// array parameter rather than one parameter per element. In and NotIn render
+// it as 'X = ANY($1)' and 'X <> ALL($1)' respectively, which keeps the query
+// text the same regardless of the number of elements so that postgres can
+// reuse its query plan.
+type ArrayParameter struct {
+	value driver.Valuer
+}
+
+// ArrayParam creates a new ArrayParameter from a slice. Only slices of ints,
+// floats, strings, bools, time.Time or UUIDs ([16]byte) are supported,
+// ArrayParam panics on anything else.
+func ArrayParam(slice interface{}) ArrayParameter {
+	value, ok := arrayParamValue(slice)
+	if !ok {
+		panic(fmt.Errorf("sq: unsupported ArrayParam type %T: only slices of ints, floats, strings, bools, time.Time or UUIDs are supported", slice))
+	}
+	return ArrayParameter{value: value}
+}
+
+// AppendSQLExclude marshals the ArrayParameter into a single placeholder whose
+// arg is the pq array.
+func (p ArrayParameter) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	buf.WriteString("?")
+	*args = append(*args, p.value)
+}
+
+// arrayParamValue converts a slice into its pq array equivalent. It reports
+// false if the slice type is not supported.
+func arrayParamValue(slice interface{}) (driver.Valuer, bool) {
+	switch s := slice.(type) {
+	case []int:
+		array := make(pq.Int64Array, len(s))
+		for i := range s {
+			array[i] = int64(s[i])
+		}
+		return array, true
+	case []int32:
+		array := make(pq.Int64Array, len(s))
+		for i := range s {
+			array[i] = int64(s[i])
+		}
+		return array, true
+	case []int64:
+		return append(pq.Int64Array{}, s...), true
+	case []float32:
+		array := make(pq.Float64Array, len(s))
+		for i := range s {
+			array[i] = float64(s[i])
+		}
+		return array, true
+	case []float64:
+		return append(pq.Float64Array{}, s...), true
+	case []string:
+		return append(pq.StringArray{}, s...), true
+	case []bool:
+		return append(pq.BoolArray{}, s...), true
+	case []time.Time:
+		return pq.GenericArray{A: append([]time.Time{}, s...)}, true
+	}
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice {
+		return nil, false
+	}
+	if elem := v.Type().Elem(); elem.Kind() != reflect.Array || !elem.ConvertibleTo(uuidType) {
+		return nil, false
+	}
+	array := make(pq.StringArray, v.Len())
+	for i := range array {
+		array[i] = formatUUID(v.Index(i).Convert(uuidType).Interface().([16]byte))
+	}
+	return array, true
+}
+
+// arrayParam reports whether v should be bound as a single array parameter,
+// either because it is an ArrayParameter or because it is a supported slice
+// with more than arrayParamThreshold elements.
+func arrayParam(v interface{}) (ArrayParameter, bool) {
+	if p, ok := v.(ArrayParameter); ok {
+		return p, true
+	}
+	if v == nil {
+		return ArrayParameter{}, false
+	}
+	if s := reflect.ValueOf(v); s.Kind() != reflect.Slice || s.Len() <= arrayParamThreshold {
+		return ArrayParameter{}, false
+	}
+	value, ok := arrayParamValue(v)
+	return ArrayParameter{value: value}, ok
+}
@@ -0,0 +1,142 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/matryer/is"
+)
+
+func TestArrayParam(t *testing.T) {
+	type TT struct {
+		description string
+		p           Predicate
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u, m, s := USERS().As("u"), MEDIA().As("m"), SESSIONS().As("s")
+	id := [16]byte{0x5e, 0x0e, 0x1d, 0x3a, 0x8e, 0x2b, 0x4a, 0xc4, 0x9f, 0x3e, 0x2b, 0x1c, 0x4d, 0x6f, 0x7a, 0x80}
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	bigIDs := make([]int, arrayParamThreshold+1)
+	wantBigIDs := make(pq.Int64Array, arrayParamThreshold+1)
+	for i := range bigIDs {
+		bigIDs[i] = i
+		wantBigIDs[i] = int64(i)
+	}
+	tests := []TT{
+		{
+			"NumberField In ints",
+			u.USER_ID.In(ArrayParam([]int{1, 2, 3})),
+			"u.user_id = ANY(?)",
+			[]interface{}{pq.Int64Array{1, 2, 3}},
+		},
+		{
+			"NumberField NotIn floats",
+			u.USER_ID.NotIn(ArrayParam([]float64{1.5, 2.5})),
+			"u.user_id <> ALL(?)",
+			[]interface{}{pq.Float64Array{1.5, 2.5}},
+		},
+		{
+			"NumberField In empty",
+			u.USER_ID.In(ArrayParam([]int64(nil))),
+			"u.user_id = ANY(?)",
+			[]interface{}{pq.Int64Array{}},
+		},
+		{
+			"StringField In strings",
+			u.EMAIL.In(ArrayParam([]string{"a", "b"})),
+			"u.email = ANY(?)",
+			[]interface{}{pq.StringArray{"a", "b"}},
+		},
+		{
+			"StringField NotIn strings",
+			u.EMAIL.NotIn(ArrayParam([]string{"a", "b"})),
+			"u.email <> ALL(?)",
+			[]interface{}{pq.StringArray{"a", "b"}},
+		},
+		{
+			"UUIDField In uuids",
+			m.UUID.In(ArrayParam([][16]byte{id})),
+			"m.uuid = ANY(?)",
+			[]interface{}{pq.StringArray{"5e0e1d3a-8e2b-4ac4-9f3e-2b1c4d6f7a80"}},
+		},
+		{
+			"UUIDField NotIn uuids",
+			m.UUID.NotIn(ArrayParam([][16]byte{id})),
+			"m.uuid <> ALL(?)",
+			[]interface{}{pq.StringArray{"5e0e1d3a-8e2b-4ac4-9f3e-2b1c4d6f7a80"}},
+		},
+		{
+			"UUIDField NotIn slice",
+			m.UUID.NotIn([][16]byte{id}),
+			"m.uuid NOT IN (?::uuid)",
+			[]interface{}{"5e0e1d3a-8e2b-4ac4-9f3e-2b1c4d6f7a80"},
+		},
+		{
+			"CustomField In bools",
+			Fieldf("?", u.USER_ID.IsNull()).In(ArrayParam([]bool{true})),
+			"u.user_id IS NULL = ANY(?)",
+			[]interface{}{pq.BoolArray{true}},
+		},
+		{
+			"times",
+			Predicatef("? = ANY(?)", s.CREATED_AT, ArrayParam([]time.Time{ts})),
+			"s.created_at = ANY(?)",
+			[]interface{}{pq.GenericArray{A: []time.Time{ts}}},
+		},
+		{
+			"slice below threshold",
+			u.USER_ID.In(bigIDs[:2]),
+			"u.user_id IN (?, ?)",
+			[]interface{}{0, 1},
+		},
+		{
+			"slice above threshold",
+			u.USER_ID.In(bigIDs),
+			"u.user_id = ANY(?)",
+			[]interface{}{wantBigIDs},
+		},
+		{
+			"slice above threshold NotIn",
+			u.USER_ID.NotIn(bigIDs),
+			"u.user_id <> ALL(?)",
+			[]interface{}{wantBigIDs},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.p.AppendSQLExclude(buf, &args, nil, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestArrayParam_Select(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	gotQuery, gotArgs := From(u).
+		Where(
+			u.DISPLAYNAME.EqString("bob"),
+			u.USER_ID.In(ArrayParam([]int{1, 2, 3})),
+			u.EMAIL.NotIn(ArrayParam([]string{"a", "b"})),
+		).
+		Select(u.USER_ID).
+		ToSQL()
+	is.Equal("SELECT u.user_id FROM public.users AS u"+
+		" WHERE u.displayname = $1 AND u.user_id = ANY($2) AND u.email <> ALL($3)", gotQuery)
+	is.Equal([]interface{}{"bob", pq.Int64Array{1, 2, 3}, pq.StringArray{"a", "b"}}, gotArgs)
+}
+
+func TestArrayParam_Panics(t *testing.T) {
+	is := is.New(t)
+	defer func() { is.True(recover() != nil) }()
+	ArrayParam([]struct{}{{}})
+}
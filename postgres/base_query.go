@@ -13,6 +13,9 @@ const (
 	Linterpolate LogFlag = 1 << iota
 	Lstats
 	Lresults
+	// Lpretty pretty prints the logged query, see ToSQLPretty. The query that
+	// is run is unaffected.
+	Lpretty
 	// Lparse
 	Lverbose = Lstats | Lresults
 )
@@ -31,10 +34,11 @@ var defaultLogger = log.New(os.Stdout, "[sq] ", log.Ldate|log.Ltime|log.Lshortfi
 // InsertQuery, UpdateQuery or DeleteQuery depending on the method that you
 // call on it.
 type BaseQuery struct {
-	DB      DB
-	Log     Logger
-	LogFlag LogFlag
-	CTEs    []CTE
+	DB           DB
+	Log          Logger
+	LogFlag      LogFlag
+	CTEs         []CTE
+	AutoValidate bool
 }
 
 // WithDefaultLog creates a new BaseQuery with the default logger and the LogFlag
@@ -52,6 +56,14 @@ func WithDB(db DB) BaseQuery {
 	}
 }
 
+// WithValidation creates a new BaseQuery whose queries are validated before
+// they are run. See SelectQuery.Validate.
+func WithValidation() BaseQuery {
+	return BaseQuery{
+		AutoValidate: true,
+	}
+}
+
 // With creates a new BaseQuery with the CTEs.
 func With(CTEs ...CTE) BaseQuery {
 	return BaseQuery{
@@ -72,6 +84,13 @@ func (q BaseQuery) WithDB(db DB) BaseQuery {
 	return q
 }
 
+// WithValidation makes the BaseQuery's queries be validated before they are
+// run. See SelectQuery.Validate.
+func (q BaseQuery) WithValidation() BaseQuery {
+	q.AutoValidate = true
+	return q
+}
+
 // With adds the CTEs to the BaseQuery
 func (q BaseQuery) With(CTEs ...CTE) BaseQuery {
 	q.CTEs = append(q.CTEs, CTEs...)
@@ -81,11 +100,12 @@ func (q BaseQuery) With(CTEs ...CTE) BaseQuery {
 // From transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) From(table Table) SelectQuery {
 	return SelectQuery{
-		FromTable: table,
-		CTEs:      q.CTEs,
-		DB:        q.DB,
-		Log:       q.Log,
-		LogFlag:   q.LogFlag,
+		FromTable:    table,
+		CTEs:         q.CTEs,
+		DB:           q.DB,
+		Log:          q.Log,
+		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
@@ -97,6 +117,7 @@ func (q BaseQuery) Select(fields ...Field) SelectQuery {
 		DB:           q.DB,
 		Log:          q.Log,
 		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
@@ -108,6 +129,7 @@ func (q BaseQuery) SelectOne() SelectQuery {
 		DB:           q.DB,
 		Log:          q.Log,
 		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
@@ -119,6 +141,7 @@ func (q BaseQuery) SelectAll() SelectQuery {
 		DB:           q.DB,
 		Log:          q.Log,
 		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
@@ -130,6 +153,7 @@ func (q BaseQuery) SelectCount() SelectQuery {
 		DB:           q.DB,
 		Log:          q.Log,
 		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
@@ -142,6 +166,7 @@ func (q BaseQuery) SelectDistinct(fields ...Field) SelectQuery {
 		DB:           q.DB,
 		Log:          q.Log,
 		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
@@ -156,6 +181,7 @@ func (q BaseQuery) SelectDistinctOn(distinctFields ...Field) func(...Field) Sele
 			DB:           q.DB,
 			Log:          q.Log,
 			LogFlag:      q.LogFlag,
+			AutoValidate: q.AutoValidate,
 		}
 	}
 }
@@ -163,79 +189,86 @@ func (q BaseQuery) SelectDistinctOn(distinctFields ...Field) func(...Field) Sele
 // Selectx transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) Selectx(mapper func(*Row), accumulator func()) SelectQuery {
 	return SelectQuery{
-		RowMapper:   mapper,
-		Accumulator: accumulator,
-		CTEs:        q.CTEs,
-		DB:          q.DB,
-		Log:         q.Log,
-		LogFlag:     q.LogFlag,
+		RowMapper:    mapper,
+		Accumulator:  accumulator,
+		CTEs:         q.CTEs,
+		DB:           q.DB,
+		Log:          q.Log,
+		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
 // SelectRowx transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) SelectRowx(mapper func(*Row)) SelectQuery {
 	return SelectQuery{
-		RowMapper: mapper,
-		CTEs:      q.CTEs,
-		DB:        q.DB,
-		Log:       q.Log,
-		LogFlag:   q.LogFlag,
+		RowMapper:    mapper,
+		CTEs:         q.CTEs,
+		DB:           q.DB,
+		Log:          q.Log,
+		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
 // InsertInto transforms the BaseQuery into an InsertQuery.
 func (q BaseQuery) InsertInto(table BaseTable) InsertQuery {
 	return InsertQuery{
-		IntoTable: table,
-		CTEs:      q.CTEs,
-		DB:        q.DB,
-		Log:       q.Log,
-		LogFlag:   q.LogFlag,
+		IntoTable:    table,
+		CTEs:         q.CTEs,
+		DB:           q.DB,
+		Log:          q.Log,
+		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
 // Update transforms the BaseQuery into an UpdateQuery.
 func (q BaseQuery) Update(table BaseTable) UpdateQuery {
 	return UpdateQuery{
-		UpdateTable: table,
-		CTEs:        q.CTEs,
-		DB:          q.DB,
-		Log:         q.Log,
-		LogFlag:     q.LogFlag,
+		UpdateTable:  table,
+		CTEs:         q.CTEs,
+		DB:           q.DB,
+		Log:          q.Log,
+		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
 // DeleteFrom transforms the BaseQuery into a DeleteQuery.
 func (q BaseQuery) DeleteFrom(table BaseTable) DeleteQuery {
 	return DeleteQuery{
-		FromTable: table,
-		CTEs:      q.CTEs,
-		DB:        q.DB,
-		Log:       q.Log,
-		LogFlag:   q.LogFlag,
+		FromTable:    table,
+		CTEs:         q.CTEs,
+		DB:           q.DB,
+		Log:          q.Log,
+		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
 // Union transforms the BaseQuery into a VariadicQuery.
 func (q BaseQuery) Union(queries ...Query) VariadicQuery {
 	return VariadicQuery{
-		topLevel: true,
-		Operator: QueryUnion,
-		Queries:  queries,
-		DB:       q.DB,
-		Log:      q.Log,
-		LogFlag:  q.LogFlag,
+		topLevel:     true,
+		Operator:     QueryUnion,
+		Queries:      queries,
+		DB:           q.DB,
+		Log:          q.Log,
+		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
 
 // UnionAll transforms the BaseQuery into a VariadicQuery.
 func (q BaseQuery) UnionAll(queries ...Query) VariadicQuery {
 	return VariadicQuery{
-		topLevel: true,
-		Operator: QueryUnionAll,
-		Queries:  queries,
-		DB:       q.DB,
-		Log:      q.Log,
-		LogFlag:  q.LogFlag,
+		topLevel:     true,
+		Operator:     QueryUnionAll,
+		Queries:      queries,
+		DB:           q.DB,
+		Log:          q.Log,
+		LogFlag:      q.LogFlag,
+		AutoValidate: q.AutoValidate,
 	}
 }
@@ -0,0 +1,228 @@
+package sq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// coalesce builds the format and values for a COALESCE() call over the field
+// and its fallbacks.
+func coalesce(field Field, fallbacks []interface{}) (string, []interface{}) {
+	format := "COALESCE(?" + strings.Repeat(", ?", len(fallbacks)) + ")"
+	return format, append([]interface{}{field}, fallbacks...)
+}
+
+// CoalesceNumber represents the COALESCE() function, which returns the first
+// non-NULL value. Each fallback may be a field or a Go value.
+func CoalesceNumber(field NumberField, fallbacks ...interface{}) NumberField {
+	format, values := coalesce(field, fallbacks)
+	return NumberField{
+		format: &format,
+		values: values,
+	}
+}
+
+// CoalesceString represents the COALESCE() function, which returns the first
+// non-NULL value. Each fallback may be a field or a Go value.
+func CoalesceString(field StringField, fallbacks ...interface{}) StringField {
+	format, values := coalesce(field, fallbacks)
+	return StringField{
+		format: &format,
+		values: values,
+	}
+}
+
+// CoalesceTime represents the COALESCE() function, which returns the first
+// non-NULL value. Each fallback may be a field or a Go value.
+func CoalesceTime(field TimeField, fallbacks ...interface{}) TimeField {
+	format, values := coalesce(field, fallbacks)
+	return TimeField{
+		format: &format,
+		values: values,
+	}
+}
+
+// CoalesceBoolean represents the COALESCE() function, which returns the first
+// non-NULL value. Each fallback may be a field or a Go value.
+func CoalesceBoolean(field BooleanField, fallbacks ...interface{}) BooleanField {
+	format, values := coalesce(field, fallbacks)
+	return BooleanField{
+		format: &format,
+		values: values,
+	}
+}
+
+// greatestOrLeast builds the format and values for a GREATEST() or LEAST()
+// call over the field and the other values.
+func greatestOrLeast(function string, field Field, values []interface{}) (string, []interface{}) {
+	format := function + "(?" + strings.Repeat(", ?", len(values)) + ")"
+	return format, append([]interface{}{field}, values...)
+}
+
+// NullIf represents the NULLIF(X, value) function, which returns NULL if the
+// NumberField is equal to the value.
+func (f NumberField) NullIf(value interface{}) NumberField {
+	return NumberFieldf("NULLIF(?, ?)", f, value)
+}
+
+// Greatest represents the GREATEST() function, which returns the largest of
+// the NumberField and the values. NULL values are ignored.
+func (f NumberField) Greatest(values ...interface{}) NumberField {
+	format, values := greatestOrLeast("GREATEST", f, values)
+	return NumberFieldf(format, values...)
+}
+
+// Least represents the LEAST() function, which returns the smallest of the
+// NumberField and the values. NULL values are ignored.
+func (f NumberField) Least(values ...interface{}) NumberField {
+	format, values := greatestOrLeast("LEAST", f, values)
+	return NumberFieldf(format, values...)
+}
+
+// NullIf represents the NULLIF(X, value) function, which returns NULL if the
+// StringField is equal to the value.
+func (f StringField) NullIf(value interface{}) StringField {
+	return StringFieldf("NULLIF(?, ?)", f, value)
+}
+
+// Greatest represents the GREATEST() function, which returns the largest of
+// the StringField and the values. NULL values are ignored.
+func (f StringField) Greatest(values ...interface{}) StringField {
+	format, values := greatestOrLeast("GREATEST", f, values)
+	return StringFieldf(format, values...)
+}
+
+// Least represents the LEAST() function, which returns the smallest of the
+// StringField and the values. NULL values are ignored.
+func (f StringField) Least(values ...interface{}) StringField {
+	format, values := greatestOrLeast("LEAST", f, values)
+	return StringFieldf(format, values...)
+}
+
+// NullIf represents the NULLIF(X, value) function, which returns NULL if the
+// TimeField is equal to the value.
+func (f TimeField) NullIf(value interface{}) TimeField {
+	return TimeFieldf("NULLIF(?, ?)", f, value)
+}
+
+// Greatest represents the GREATEST() function, which returns the latest of
+// the TimeField and the values. NULL values are ignored.
+func (f TimeField) Greatest(values ...interface{}) TimeField {
+	format, values := greatestOrLeast("GREATEST", f, values)
+	return TimeFieldf(format, values...)
+}
+
+// Least represents the LEAST() function, which returns the earliest of the
+// TimeField and the values. NULL values are ignored.
+func (f TimeField) Least(values ...interface{}) TimeField {
+	format, values := greatestOrLeast("LEAST", f, values)
+	return TimeFieldf(format, values...)
+}
+
+// CastExpression represents a 'CAST(X AS type)' expression. Use AsNumber,
+// AsString, AsTime or AsBoolean to turn it into a field of the matching kind.
+type CastExpression struct {
+	value    interface{}
+	typename string
+}
+
+// Cast returns a CastExpression that casts the value (a field or a Go value)
+// to the type e.g. Cast(u.SCORE, "numeric(10, 2)").AsNumber(). The type cannot be
+// passed as an argument, so Cast panics unless the type is a name with an
+// optional precision and scale e.g. 'varchar(255)' or 'numeric(10, 2)', or one
+// of the multi-word types in castTypes, optionally followed by '[]'.
+func Cast(value interface{}, typename string) CastExpression {
+	if typename == "" {
+		panic(fmt.Errorf("sq: empty cast type"))
+	}
+	if !validCastType(typename) {
+		panic(fmt.Errorf("sq: invalid cast type %q", typename))
+	}
+	return CastExpression{
+		value:    value,
+		typename: typename,
+	}
+}
+
+// castTypes are the multi-word types accepted by Cast.
+var castTypes = []string{
+	"bit varying",
+	"character varying",
+	"double precision",
+	"time with time zone",
+	"time without time zone",
+	"timestamp with time zone",
+	"timestamp without time zone",
+}
+
+// validCastType reports whether the typename is a type accepted by Cast.
+func validCastType(typename string) bool {
+	typename = strings.TrimSuffix(typename, "[]")
+	for _, name := range castTypes {
+		if strings.EqualFold(typename, name) {
+			return true
+		}
+	}
+	// name
+	i := 0
+	for ; i < len(typename); i++ {
+		c := typename[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || i > 0 && c >= '0' && c <= '9') {
+			break
+		}
+	}
+	if i == 0 {
+		return false
+	}
+	rest := typename[i:]
+	if rest == "" {
+		return true
+	}
+	// (precision[, scale])
+	if rest[0] != '(' || rest[len(rest)-1] != ')' {
+		return false
+	}
+	parts := strings.Split(rest[1:len(rest)-1], ",")
+	if len(parts) > 2 {
+		return false
+	}
+	for i, part := range parts {
+		if i > 0 {
+			part = strings.TrimPrefix(part, " ")
+		}
+		if part == "" {
+			return false
+		}
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// format returns the format string of the CastExpression.
+func (c CastExpression) format() string {
+	return "CAST(? AS " + c.typename + ")"
+}
+
+// AsNumber returns the CastExpression as a NumberField.
+func (c CastExpression) AsNumber() NumberField {
+	return NumberFieldf(c.format(), c.value)
+}
+
+// AsString returns the CastExpression as a StringField.
+func (c CastExpression) AsString() StringField {
+	return StringFieldf(c.format(), c.value)
+}
+
+// AsTime returns the CastExpression as a TimeField.
+func (c CastExpression) AsTime() TimeField {
+	return TimeFieldf(c.format(), c.value)
+}
+
+// AsBoolean returns the CastExpression as a BooleanField.
+func (c CastExpression) AsBoolean() BooleanField {
+	return BooleanFieldf(c.format(), c.value)
+}
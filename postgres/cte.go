@@ -1,6 +1,7 @@
 package sq
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -11,16 +12,22 @@ const (
 	metadataName      = "𝑛𝑎𝑚𝑒"
 	metadataAlias     = "𝑎𝑙𝑖𝑎𝑠"
 	metadataColumns   = "𝑐𝑜𝑙𝑢𝑚𝑛𝑠"
+	metadataMaterial  = "𝑚𝑎𝑡𝑒𝑟𝑖𝑎𝑙"
+	metadataSearch    = "𝑠𝑒𝑎𝑟𝑐ℎ"
+	metadataCycle     = "𝑐𝑦𝑐𝑙𝑒"
 )
 
 // CTE represents an SQL CTE.
 type CTE map[string]CustomField
 
-func appendCTEs(buf *strings.Builder, args *[]interface{}, CTEs []CTE, fromTable Table, joinTables []JoinTable) {
+func appendCTEs(buf *strings.Builder, args *[]interface{}, CTEs []CTE, fromTable Table, joinTables []JoinTable, format sqlFormat) {
 	type TmpCTE struct {
-		name    string
-		columns []string
-		query   Query
+		name         string
+		columns      []string
+		query        Query
+		materialized string
+		search       string
+		cycle        string
 	}
 	var tmpCTEs []TmpCTE
 	cteNames := map[string]bool{} // track CTE names we have already seen; used to remove duplicates
@@ -39,9 +46,12 @@ func appendCTEs(buf *strings.Builder, args *[]interface{}, CTEs []CTE, fromTable
 			hasRecursiveCTE = true
 		}
 		tmpCTEs = append(tmpCTEs, TmpCTE{
-			name:    name,
-			columns: cte.GetColumns(),
-			query:   cte.GetQuery(),
+			name:         name,
+			columns:      cte.GetColumns(),
+			query:        cte.GetQuery(),
+			materialized: cte[metadataMaterial].Format,
+			search:       cte[metadataSearch].Format,
+			cycle:        cte[metadataCycle].Format,
 		})
 	}
 	for _, cte := range CTEs {
@@ -69,19 +79,32 @@ func appendCTEs(buf *strings.Builder, args *[]interface{}, CTEs []CTE, fromTable
 			buf.WriteString(strings.Join(cte.columns, ", "))
 			buf.WriteString(")")
 		}
-		buf.WriteString(" AS (")
+		buf.WriteString(" AS ")
+		if cte.materialized != "" {
+			buf.WriteString(cte.materialized)
+			buf.WriteString(" ")
+		}
+		buf.WriteString("(")
 		switch q := cte.query.(type) {
 		case nil:
 			buf.WriteString("NULL")
 		case VariadicQuery:
 			q.topLevel = true
-			q.NestThis().AppendSQL(buf, args, nil)
+			format.appendQuery(buf, args, q)
 		default:
-			q.NestThis().AppendSQL(buf, args, nil)
+			format.appendQuery(buf, args, q)
 		}
 		buf.WriteString(")")
+		if cte.search != "" {
+			buf.WriteString(" ")
+			buf.WriteString(cte.search)
+		}
+		if cte.cycle != "" {
+			buf.WriteString(" ")
+			buf.WriteString(cte.cycle)
+		}
 	}
-	buf.WriteString(" ")
+	format.newline(buf)
 }
 
 // CTE converts a SelectQuery into a CTE.
@@ -92,6 +115,12 @@ func (q SelectQuery) CTE(name string, columns ...string) CTE {
 		metadataAlias:   {Values: []interface{}{""}},
 		metadataColumns: {Values: []interface{}{columns}},
 	}
+	if len(columns) > 0 {
+		for _, column := range columns {
+			cte[column] = CustomField{Format: name + "." + column}
+		}
+		return cte
+	}
 	for _, field := range q.SelectFields {
 		column := getAliasOrName(field)
 		cte[column] = CustomField{Format: name + "." + column}
@@ -107,6 +136,12 @@ func (q InsertQuery) CTE(name string, columns ...string) CTE {
 		metadataAlias:   {Values: []interface{}{""}},
 		metadataColumns: {Values: []interface{}{columns}},
 	}
+	if len(columns) > 0 {
+		for _, column := range columns {
+			cte[column] = CustomField{Format: name + "." + column}
+		}
+		return cte
+	}
 	for _, field := range q.ReturningFields {
 		column := getAliasOrName(field)
 		cte[column] = CustomField{Format: name + "." + column}
@@ -122,6 +157,12 @@ func (q UpdateQuery) CTE(name string, columns ...string) CTE {
 		metadataAlias:   {Values: []interface{}{""}},
 		metadataColumns: {Values: []interface{}{columns}},
 	}
+	if len(columns) > 0 {
+		for _, column := range columns {
+			cte[column] = CustomField{Format: name + "." + column}
+		}
+		return cte
+	}
 	for _, field := range q.ReturningFields {
 		column := getAliasOrName(field)
 		cte[column] = CustomField{Format: name + "." + column}
@@ -137,6 +178,12 @@ func (q DeleteQuery) CTE(name string, columns ...string) CTE {
 		metadataAlias:   {Values: []interface{}{""}},
 		metadataColumns: {Values: []interface{}{columns}},
 	}
+	if len(columns) > 0 {
+		for _, column := range columns {
+			cte[column] = CustomField{Format: name + "." + column}
+		}
+		return cte
+	}
 	for _, field := range q.ReturningFields {
 		column := getAliasOrName(field)
 		cte[column] = CustomField{Format: name + "." + column}
@@ -193,9 +240,15 @@ func (cte CTE) As(alias string) CTE {
 		metadataAlias:   {Values: []interface{}{alias}},
 		metadataColumns: {Values: []interface{}{cte.GetColumns()}},
 	}
+	for _, key := range []string{metadataRecursive, metadataMaterial, metadataSearch, metadataCycle} {
+		if field, ok := cte[key]; ok {
+			newcte[key] = field
+		}
+	}
 	for column := range cte {
 		switch column {
-		case metadataQuery, metadataName, metadataAlias, metadataColumns:
+		case metadataQuery, metadataRecursive, metadataName, metadataAlias, metadataColumns,
+			metadataMaterial, metadataSearch, metadataCycle:
 			continue
 		}
 		newcte[column] = CustomField{Format: alias + "." + column}
@@ -263,6 +316,176 @@ func (cte CTE) GetAlias() string {
 	return ""
 }
 
+// column returns the name of the CTE column. It panics if the CTE does not
+// have that column, which is either one of the columns passed to CTE() or one
+// of the fields selected by the CTE's query.
+func (cte CTE) column(name string) string {
+	switch name {
+	case metadataQuery, metadataRecursive, metadataName, metadataAlias, metadataColumns,
+		metadataMaterial, metadataSearch, metadataCycle:
+	default:
+		if _, ok := cte[name]; ok {
+			return name
+		}
+	}
+	panic(fmt.Errorf("sq: CTE %s has no column %s", cte.GetName(), name))
+}
+
+// NumberField returns the CTE column as a NumberField. It panics if the CTE does
+// not have that column.
+func (cte CTE) NumberField(column string) NumberField {
+	return NewNumberField(cte.column(column), cte)
+}
+
+// StringField returns the CTE column as a StringField. It panics if the CTE does
+// not have that column.
+func (cte CTE) StringField(column string) StringField {
+	return NewStringField(cte.column(column), cte)
+}
+
+// BooleanField returns the CTE column as a BooleanField. It panics if the CTE does
+// not have that column.
+func (cte CTE) BooleanField(column string) BooleanField {
+	return NewBooleanField(cte.column(column), cte)
+}
+
+// TimeField returns the CTE column as a TimeField. It panics if the CTE does
+// not have that column.
+func (cte CTE) TimeField(column string) TimeField {
+	return NewTimeField(cte.column(column), cte)
+}
+
+// JSONField returns the CTE column as a JSONField. It panics if the CTE does
+// not have that column.
+func (cte CTE) JSONField(column string) JSONField {
+	return NewJSONField(cte.column(column), cte)
+}
+
+// BinaryField returns the CTE column as a BinaryField. It panics if the CTE does
+// not have that column.
+func (cte CTE) BinaryField(column string) BinaryField {
+	return NewBinaryField(cte.column(column), cte)
+}
+
+// UUIDField returns the CTE column as a UUIDField. It panics if the CTE does
+// not have that column.
+func (cte CTE) UUIDField(column string) UUIDField {
+	return NewUUIDField(cte.column(column), cte)
+}
+
+// ArrayField returns the CTE column as an ArrayField. It panics if the CTE does
+// not have that column.
+func (cte CTE) ArrayField(column string) ArrayField {
+	return NewArrayField(cte.column(column), cte)
+}
+
+// Materialized returns a new CTE that is always materialized i.e. 'AS
+// MATERIALIZED (query)'. Requires Postgres 12 and above.
+func (cte CTE) Materialized() CTE {
+	return cte.with(metadataMaterial, CustomField{Format: "MATERIALIZED"})
+}
+
+// NotMaterialized returns a new CTE that is never materialized i.e. 'AS NOT
+// MATERIALIZED (query)', so that postgres may inline it into the outer query.
+// Requires Postgres 12 and above.
+func (cte CTE) NotMaterialized() CTE {
+	return cte.with(metadataMaterial, CustomField{Format: "NOT MATERIALIZED"})
+}
+
+// SearchDepthFirst returns a new recursive CTE with a 'SEARCH DEPTH FIRST BY
+// columns SET sequenceColumn' clause. The sequenceColumn is added to the CTE
+// and can be used to ORDER BY the rows in depth-first order. It panics if the
+// CTE is not recursive, if any of the columns are not in the CTE or if the
+// sequenceColumn is not a valid identifier. Requires Postgres 14 and above.
+func (cte CTE) SearchDepthFirst(sequenceColumn string, columns ...string) CTE {
+	return cte.search("DEPTH", sequenceColumn, columns)
+}
+
+// SearchBreadthFirst returns a new recursive CTE with a 'SEARCH BREADTH FIRST
+// BY columns SET sequenceColumn' clause. The sequenceColumn is added to the
+// CTE and can be used to ORDER BY the rows in breadth-first order. It panics if
+// the CTE is not recursive, if any of the columns are not in the CTE or if the
+// sequenceColumn is not a valid identifier. Requires Postgres 14 and above.
+func (cte CTE) SearchBreadthFirst(sequenceColumn string, columns ...string) CTE {
+	return cte.search("BREADTH", sequenceColumn, columns)
+}
+
+func (cte CTE) search(order, sequenceColumn string, columns []string) CTE {
+	if !cte.IsRecursive() {
+		panic(fmt.Errorf("sq: SEARCH can only be applied to a recursive CTE"))
+	}
+	if len(columns) == 0 {
+		panic(fmt.Errorf("sq: SEARCH requires at least one column"))
+	}
+	for _, column := range columns {
+		cte.column(column)
+	}
+	cte.newColumn("SEARCH", sequenceColumn)
+	newcte := cte.with(metadataSearch, CustomField{
+		Format: "SEARCH " + order + " FIRST BY " + strings.Join(columns, ", ") + " SET " + sequenceColumn,
+	})
+	newcte[sequenceColumn] = CustomField{Format: cte.GetName() + "." + sequenceColumn}
+	return newcte
+}
+
+// Cycle returns a new recursive CTE with a 'CYCLE columns SET markColumn USING
+// pathColumn' clause, which stops the recursion once a row with the same
+// columns has been seen before. The markColumn (a boolean that is true on the
+// row that closed the cycle, get it with cte.BooleanField) and the pathColumn
+// (the array of visited rows) are added to the CTE. It panics if the CTE is not
+// recursive, if any of the columns are not in the CTE or if the markColumn or
+// pathColumn is not a valid identifier. Requires Postgres 14 and above.
+func (cte CTE) Cycle(markColumn, pathColumn string, columns ...string) CTE {
+	if !cte.IsRecursive() {
+		panic(fmt.Errorf("sq: CYCLE can only be applied to a recursive CTE"))
+	}
+	if len(columns) == 0 {
+		panic(fmt.Errorf("sq: CYCLE requires at least one column"))
+	}
+	for _, column := range columns {
+		cte.column(column)
+	}
+	cte.newColumn("CYCLE", markColumn)
+	cte.newColumn("CYCLE", pathColumn)
+	if markColumn == pathColumn {
+		panic(fmt.Errorf("sq: CYCLE mark and path columns are both named %s", markColumn))
+	}
+	newcte := cte.with(metadataCycle, CustomField{
+		Format: "CYCLE " + strings.Join(columns, ", ") + " SET " + markColumn + " USING " + pathColumn,
+	})
+	newcte[markColumn] = CustomField{Format: cte.GetName() + "." + markColumn}
+	newcte[pathColumn] = CustomField{Format: cte.GetName() + "." + pathColumn}
+	return newcte
+}
+
+// newColumn checks the name of a column that the clause adds to the CTE. It
+// panics if the name is not a plain identifier, since it is written into the
+// query as is, or if the CTE already has a column with that name.
+func (cte CTE) newColumn(clause, name string) {
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || i > 0 && c >= '0' && c <= '9') {
+			panic(fmt.Errorf("sq: %s column %q is not a valid identifier", clause, name))
+		}
+	}
+	if name == "" {
+		panic(fmt.Errorf("sq: %s column name is empty", clause))
+	}
+	if _, ok := cte[name]; ok {
+		panic(fmt.Errorf("sq: CTE %s already has a column %s", cte.GetName(), name))
+	}
+}
+
+// with returns a copy of the CTE with the key set to field.
+func (cte CTE) with(key string, field CustomField) CTE {
+	newcte := make(CTE, len(cte)+1)
+	for k, v := range cte {
+		newcte[k] = v
+	}
+	newcte[key] = field
+	return newcte
+}
+
 // RecursiveCTE constructs a new recursive CTE.
 func RecursiveCTE(name string, columns ...string) CTE {
 	cte := map[string]CustomField{
@@ -356,3 +579,33 @@ func (cte *IntermediateCTE) union(queries []Query, operator VariadicQueryOperato
 	}}}
 	return CTE(*cte)
 }
+
+// Materialized returns a new IntermediateCTE that is always materialized. See
+// CTE.Materialized.
+func (cte IntermediateCTE) Materialized() IntermediateCTE {
+	return IntermediateCTE(CTE(cte).Materialized())
+}
+
+// NotMaterialized returns a new IntermediateCTE that is never materialized.
+// See CTE.NotMaterialized.
+func (cte IntermediateCTE) NotMaterialized() IntermediateCTE {
+	return IntermediateCTE(CTE(cte).NotMaterialized())
+}
+
+// SearchDepthFirst returns a new IntermediateCTE with a 'SEARCH DEPTH FIRST BY
+// columns SET sequenceColumn' clause. See CTE.SearchDepthFirst.
+func (cte IntermediateCTE) SearchDepthFirst(sequenceColumn string, columns ...string) IntermediateCTE {
+	return IntermediateCTE(CTE(cte).SearchDepthFirst(sequenceColumn, columns...))
+}
+
+// SearchBreadthFirst returns a new IntermediateCTE with a 'SEARCH BREADTH
+// FIRST BY columns SET sequenceColumn' clause. See CTE.SearchBreadthFirst.
+func (cte IntermediateCTE) SearchBreadthFirst(sequenceColumn string, columns ...string) IntermediateCTE {
+	return IntermediateCTE(CTE(cte).SearchBreadthFirst(sequenceColumn, columns...))
+}
+
+// Cycle returns a new IntermediateCTE with a 'CYCLE columns SET markColumn
+// USING pathColumn' clause. See CTE.Cycle.
+func (cte IntermediateCTE) Cycle(markColumn, pathColumn string, columns ...string) IntermediateCTE {
+	return IntermediateCTE(CTE(cte).Cycle(markColumn, pathColumn, columns...))
+}
@@ -205,6 +205,123 @@ func TestCTE(t *testing.T) {
 			tt.wantArgs = []interface{}{1, "apple", 2, 3}
 			return tt
 		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Select CTE typed columns"
+			u := USERS().As("u")
+			cte := Select(u.USER_ID, u.DISPLAYNAME, Count().As("count")).
+				From(u).
+				GroupBy(u.USER_ID, u.DISPLAYNAME).
+				CTE("cte", "id", "name", "total")
+			id, name, total := cte.NumberField("id"), cte.StringField("name"), cte.NumberField("total")
+			tt.q = Select(id, name, total).From(cte).Where(total.GtInt(1), name.LikeString("a%")).OrderBy(id.Desc())
+			tt.wantQuery = "WITH cte (id, name, total) AS" +
+				" (SELECT u.user_id, u.displayname, COUNT(*) AS count FROM public.users AS u GROUP BY u.user_id, u.displayname)" +
+				" SELECT cte.id, cte.name, cte.total FROM cte WHERE cte.total > $1 AND cte.name LIKE $2 ORDER BY cte.id DESC"
+			tt.wantArgs = []interface{}{1, "a%"}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Select CTE aliased typed columns"
+			u := USERS().As("u")
+			cte := Select(u.USER_ID, u.EMAIL).From(u).CTE("cte")
+			c := cte.As("c")
+			tt.q = Select(c.NumberField("user_id")).From(c).Where(c.StringField("email").EqString("bob@email.com"))
+			tt.wantQuery = "WITH cte AS" +
+				" (SELECT u.user_id, u.email FROM public.users AS u)" +
+				" SELECT c.user_id FROM cte AS c WHERE c.email = $1"
+			tt.wantArgs = []interface{}{"bob@email.com"}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Recursive CTE typed columns"
+			tens := RecursiveCTE("tens", "n")
+			n := tens.NumberField("n")
+			tens = tens.
+				Initial(Select(Int(10))).
+				UnionAll(
+					Select(n.Add(10)).From(tens).Where(n.Add(10).LeInt(100)),
+				)
+			tt.q = Select(n).From(tens)
+			tt.wantQuery = "WITH RECURSIVE tens (n) AS" +
+				" (SELECT $1" +
+				" UNION ALL" +
+				" SELECT (tens.n + $2) FROM tens WHERE (tens.n + $3) <= $4)" +
+				" SELECT tens.n FROM tens"
+			tt.wantArgs = []interface{}{10, 10, 10, 100}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "CTE materialized"
+			u := USERS().As("u")
+			cte := Select(u.USER_ID).From(u).CTE("cte").Materialized()
+			c := cte.As("c")
+			tt.q = Select(c.NumberField("user_id")).From(c)
+			tt.wantQuery = "WITH cte AS MATERIALIZED" +
+				" (SELECT u.user_id FROM public.users AS u)" +
+				" SELECT c.user_id FROM cte AS c"
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "CTE not materialized"
+			u := USERS().As("u")
+			cte := Select(u.USER_ID).From(u).CTE("cte").NotMaterialized()
+			tt.q = Select(cte.NumberField("user_id")).From(cte)
+			tt.wantQuery = "WITH cte AS NOT MATERIALIZED" +
+				" (SELECT u.user_id FROM public.users AS u)" +
+				" SELECT cte.user_id FROM cte"
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Recursive CTE SEARCH DEPTH FIRST and CYCLE"
+			ur := USER_ROLES().As("ur")
+			tree := RecursiveCTE("tree", "user_role_id", "user_id")
+			id, parent := tree.NumberField("user_role_id"), tree.NumberField("user_id")
+			tree = tree.
+				Initial(Select(ur.USER_ROLE_ID, ur.USER_ID).From(ur).Where(ur.USER_ID.IsNull())).
+				UnionAll(
+					Select(ur.USER_ROLE_ID, ur.USER_ID).From(ur).Join(tree, ur.USER_ID.Eq(id)),
+				).
+				SearchDepthFirst("ordercol", "user_role_id").
+				Cycle("is_cycle", "path", "user_role_id")
+			tt.q = Select(id, parent).
+				From(tree).
+				Where(tree.BooleanField("is_cycle").Not()).
+				OrderBy(tree["ordercol"])
+			tt.wantQuery = "WITH RECURSIVE tree (user_role_id, user_id) AS" +
+				" (SELECT ur.user_role_id, ur.user_id FROM public.user_roles AS ur WHERE ur.user_id IS NULL" +
+				" UNION ALL" +
+				" SELECT ur.user_role_id, ur.user_id FROM public.user_roles AS ur JOIN tree ON ur.user_id = tree.user_role_id)" +
+				" SEARCH DEPTH FIRST BY user_role_id SET ordercol" +
+				" CYCLE user_role_id SET is_cycle USING path" +
+				" SELECT tree.user_role_id, tree.user_id FROM tree WHERE NOT tree.is_cycle ORDER BY tree.ordercol"
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "IntermediateCTE SEARCH BREADTH FIRST"
+			tens := RecursiveCTE("tens", "n")
+			n := tens.NumberField("n")
+			tens = tens.
+				Initial(Select(Int(10))).
+				NotMaterialized().
+				SearchBreadthFirst("ordercol", "n").
+				UnionAll(Select(n.Add(10)).From(tens).Where(n.LtInt(100)))
+			tt.q = Select(n).From(tens).OrderBy(tens["ordercol"])
+			tt.wantQuery = "WITH RECURSIVE tens (n) AS NOT MATERIALIZED" +
+				" (SELECT $1" +
+				" UNION ALL" +
+				" SELECT (tens.n + $2) FROM tens WHERE tens.n < $3)" +
+				" SEARCH BREADTH FIRST BY n SET ordercol" +
+				" SELECT tens.n FROM tens ORDER BY tens.ordercol"
+			tt.wantArgs = []interface{}{10, 10, 100}
+			return tt
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -219,3 +336,48 @@ func TestCTE(t *testing.T) {
 		})
 	}
 }
+
+func TestCTE_TypedColumnPanics(t *testing.T) {
+	u := USERS().As("u")
+	cte := Select(u.USER_ID, u.EMAIL).From(u).CTE("cte", "id", "email")
+	tests := []string{"user_id", "idd", metadataQuery}
+	for _, column := range tests {
+		column := column
+		t.Run(column, func(t *testing.T) {
+			is := is.New(t)
+			defer func() { is.True(recover() != nil) }()
+			cte.NumberField(column)
+		})
+	}
+}
+
+func TestCTE_SearchCyclePanics(t *testing.T) {
+	u := USERS().As("u")
+	cte := Select(u.USER_ID).From(u).CTE("cte")
+	tree := RecursiveCTE("tree", "id")
+	tests := []struct {
+		description string
+		f           func()
+	}{
+		{"SEARCH on non recursive CTE", func() { cte.SearchDepthFirst("ordercol", "user_id") }},
+		{"CYCLE on non recursive CTE", func() { cte.Cycle("is_cycle", "path", "user_id") }},
+		{"SEARCH without columns", func() { tree.SearchBreadthFirst("ordercol") }},
+		{"CYCLE without columns", func() { tree.Cycle("is_cycle", "path") }},
+		{"SEARCH unknown column", func() { tree.SearchDepthFirst("ordercol", "parent_id") }},
+		{"CYCLE unknown column", func() { tree.Cycle("is_cycle", "path", "parent_id") }},
+		{"SEARCH invalid sequence column", func() { tree.SearchDepthFirst("ordercol; DROP TABLE users", "id") }},
+		{"SEARCH empty sequence column", func() { tree.SearchBreadthFirst("", "id") }},
+		{"SEARCH existing sequence column", func() { tree.SearchBreadthFirst("id", "id") }},
+		{"CYCLE invalid mark column", func() { tree.Cycle("is cycle", "path", "id") }},
+		{"CYCLE invalid path column", func() { tree.Cycle("is_cycle", "1path", "id") }},
+		{"CYCLE same mark and path column", func() { tree.Cycle("path", "path", "id") }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			defer func() { is.True(recover() != nil) }()
+			tt.f()
+		})
+	}
+}
@@ -146,8 +146,15 @@ func (f CustomField) Le(v interface{}) Predicate {
 	}
 }
 
-// In returns an 'X IN (Y)' Predicate.
+// In returns an 'X IN (Y)' Predicate. If Y is an ArrayParameter or a slice of
+// more than 1000 elements, it is bound as a single array i.e. 'X = ANY($1)'.
 func (f CustomField) In(v interface{}) Predicate {
+	if p, ok := arrayParam(v); ok {
+		return CustomPredicate{
+			Format: "? = ANY(?)",
+			Values: []interface{}{f, p},
+		}
+	}
 	var format string
 	var values []interface{}
 	switch v := v.(type) {
@@ -15,6 +15,7 @@ import (
 // DeleteQuery represents a DELETE query.
 type DeleteQuery struct {
 	nested bool
+	format sqlFormat
 	// WITH
 	CTEs []CTE
 	// DELETE FROM
@@ -30,6 +31,9 @@ type DeleteQuery struct {
 	DB          DB
 	RowMapper   func(*Row)
 	Accumulator func()
+	// AutoValidate makes Fetch and Exec call Validate before running the
+	// query.
+	AutoValidate bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -49,7 +53,7 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
 	// WITH
 	if !q.nested {
-		appendCTEs(buf, args, q.CTEs, nil, q.JoinTables)
+		appendCTEs(buf, args, q.CTEs, nil, q.JoinTables, q.format)
 	}
 	// DELETE FROM
 	buf.WriteString("DELETE FROM ")
@@ -59,7 +63,7 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 		switch v := q.FromTable.(type) {
 		case Query:
 			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args, nil)
+			q.format.appendQuery(buf, args, v)
 			buf.WriteString(")")
 		default:
 			q.FromTable.AppendSQL(buf, args, nil)
@@ -72,11 +76,12 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// USING
 	if q.UsingTable != nil {
-		buf.WriteString(" USING ")
+		q.format.newline(buf)
+		buf.WriteString("USING ")
 		switch v := q.UsingTable.(type) {
 		case Query:
 			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args, nil)
+			q.format.appendQuery(buf, args, v)
 			buf.WriteString(")")
 		default:
 			q.FromTable.AppendSQL(buf, args, nil)
@@ -89,18 +94,19 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
-		buf.WriteString(" ")
-		q.JoinTables.AppendSQL(buf, args, nil)
+		q.format.appendJoinTables(buf, args, q.JoinTables)
 	}
 	// WHERE
 	if len(q.WherePredicate.Predicates) > 0 {
-		buf.WriteString(" WHERE ")
+		q.format.newline(buf)
+		buf.WriteString("WHERE ")
 		q.WherePredicate.toplevel = true
-		q.WherePredicate.AppendSQLExclude(buf, args, nil, nil)
+		q.format.appendPredicate(buf, args, q.WherePredicate, nil)
 	}
 	// RETURNING
 	if len(q.ReturningFields) > 0 {
-		buf.WriteString(" RETURNING ")
+		q.format.newline(buf)
+		buf.WriteString("RETURNING ")
 		q.ReturningFields.AppendSQLExcludeWithAlias(buf, args, nil, nil)
 	}
 	if !q.nested {
@@ -108,15 +114,21 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 		buf.Reset()
 		questionToDollarPlaceholders(buf, query)
 		if q.Log != nil {
+			logQuery, logArgs := buf.String(), *args
+			interpolate := questionInterpolate
+			if Lpretty&q.LogFlag != 0 && !q.format.pretty {
+				logQuery, logArgs = prettyLogQuery(q)
+				query, interpolate = logQuery, dollarInterpolate
+			}
 			var logOutput string
 			switch {
 			case Lstats&q.LogFlag != 0:
-				logOutput = "\n----[ Executing query ]----\n" + buf.String() + " " + fmt.Sprint(*args) +
-					"\n----[ with bind values ]----\n" + questionInterpolate(query, *args...)
+				logOutput = "\n----[ Executing query ]----\n" + logQuery + " " + fmt.Sprint(logArgs) +
+					"\n----[ with bind values ]----\n" + interpolate(query, logArgs...)
 			case Linterpolate&q.LogFlag != 0:
-				logOutput = questionInterpolate(query, *args...)
+				logOutput = interpolate(query, logArgs...)
 			default:
-				logOutput = buf.String() + " " + fmt.Sprint(*args)
+				logOutput = logQuery + " " + fmt.Sprint(logArgs)
 			}
 			switch q.Log.(type) {
 			case *log.Logger:
@@ -296,6 +308,7 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, int64(rowcount), elapsed, err)
 		if Lresults&q.LogFlag != 0 && rowcount > 5 {
 			logBuf.WriteString("\n...")
 		}
@@ -318,10 +331,16 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.ReturningFields = r.fields
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return err
+		}
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
 	} else {
@@ -396,6 +415,11 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 		db = q.DB
 	}
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return rowsAffected, err
+		}
+	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
 	defer func() {
@@ -403,6 +427,7 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, rowsAffected, elapsed, err)
 		if Lstats&q.LogFlag != 0 && ErowsAffected&flag != 0 {
 			logBuf.WriteString("\n(Deleted ")
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
@@ -424,6 +449,7 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
@@ -247,6 +247,7 @@ type TABLE_MEDIA struct {
 	NAME        StringField
 	TYPE        StringField
 	UPDATED_AT  TimeField
+	UUID        UUIDField
 }
 
 // MEDIA creates an instance of the public.media table.
@@ -262,6 +263,7 @@ func MEDIA() TABLE_MEDIA {
 	tbl.NAME = NewStringField("name", tbl.TableInfo)
 	tbl.TYPE = NewStringField("type", tbl.TableInfo)
 	tbl.UPDATED_AT = NewTimeField("updated_at", tbl.TableInfo)
+	tbl.UUID = NewUUIDField("uuid", tbl.TableInfo)
 	return tbl
 }
 
@@ -0,0 +1,201 @@
+package sq
+
+import (
+	"context"
+	"hash/fnv"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Fingerprint returns the normalized SQL of a query and its 64-bit FNV-1a hash,
+// for aggregating metrics per query shape. The normalized SQL is the query as
+// ToSQL would render it, except that lists of values are collapsed into a
+// single placeholder wherever they appear in the query: 'x IN (?, ?, ?)',
+// 'x IN (?, ?)' and 'x = ANY(?)' all become 'x IN (?)'. The query's args are
+// discarded, so queries that differ only in their arguments share a
+// fingerprint. The fields of a SelectQuery with a mapper function are the ones
+// that the mapper selects, as in Fetch.
+func Fingerprint(query Query) (normalizedSQL string, hash uint64) {
+	switch q := query.(type) {
+	case nil:
+		return "", 0
+	case SelectQuery:
+		if q.RowMapper != nil {
+			// like Fetch, the fields are those of the mapper
+			r := &Row{}
+			q.RowMapper(r)
+			q.SelectFields = r.fields
+		}
+		q.Log = nil
+		query = q
+	case InsertQuery:
+		q.Log = nil
+		query = q
+	case UpdateQuery:
+		q.Log = nil
+		query = q
+	case DeleteQuery:
+		q.Log = nil
+		query = q
+	case VariadicQuery:
+		q.Log = nil
+		query = q
+	}
+	buf := &strings.Builder{}
+	args := []interface{}{fingerprintArg{}}
+	query.AppendSQL(buf, &args, nil)
+	normalizedSQL = buf.String()
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(normalizedSQL))
+	return normalizedSQL, h.Sum64()
+}
+
+// fingerprintArg is the first arg of a query that is being rendered by
+// Fingerprint. Since args are threaded through the rendering of every part of
+// a query, it tells appendSQLValue to collapse lists of values wherever they
+// are.
+type fingerprintArg struct{}
+
+// fingerprinting reports whether the args are those of a query that is being
+// rendered by Fingerprint.
+func fingerprinting(args *[]interface{}) bool {
+	if args == nil || len(*args) == 0 {
+		return false
+	}
+	_, ok := (*args)[0].(fingerprintArg)
+	return ok
+}
+
+// QueryStats describes a query that was run by Fetch or Exec.
+type QueryStats struct {
+	// Query and Fingerprint are the results of calling Fingerprint on the
+	// query.
+	Query       string
+	Fingerprint uint64
+	// RowCount is the number of rows fetched, or the number of rows affected
+	// for Exec if the ErowsAffected flag was passed in.
+	RowCount int64
+	Elapsed  time.Duration
+	Err      error
+}
+
+// StatsLogger is a Logger that also receives the QueryStats of every query
+// that it logs. If a query's Log is a StatsLogger, Fetch and Exec pass it the
+// QueryStats after the query has run regardless of the LogFlag.
+type StatsLogger interface {
+	Logger
+	LogQueryStats(stats QueryStats)
+}
+
+// FingerprintDB is a DB that is told the Fingerprint of every query that Fetch
+// and Exec run against it, such as the fake database of the sqtest package. If
+// WantsFingerprint returns true, the normalized SQL returned by Fingerprint is
+// put into the context that is passed to QueryContext and ExecContext, and can
+// be read with ContextFingerprint.
+type FingerprintDB interface {
+	DB
+	WantsFingerprint() bool
+}
+
+type fingerprintContextKey struct{}
+
+// ContextFingerprint returns the normalized SQL of the query that is being run,
+// if the query was run by Fetch or Exec against a FingerprintDB.
+func ContextFingerprint(ctx context.Context) (normalizedSQL string, ok bool) {
+	normalizedSQL, ok = ctx.Value(fingerprintContextKey{}).(string)
+	return normalizedSQL, ok
+}
+
+// fingerprintContext returns the context to run the query with. If db is a
+// FingerprintDB that wants fingerprints, the query's Fingerprint is added to
+// the context, see ContextFingerprint.
+func fingerprintContext(ctx context.Context, db DB, q Query) context.Context {
+	if fdb, ok := db.(FingerprintDB); !ok || !fdb.WantsFingerprint() {
+		return ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	normalizedSQL, _ := Fingerprint(q)
+	return context.WithValue(ctx, fingerprintContextKey{}, normalizedSQL)
+}
+
+// logQueryStats passes the QueryStats to the logger if it is a StatsLogger.
+func logQueryStats(logger Logger, q Query, rowCount int64, elapsed time.Duration, err error) {
+	statsLogger, ok := logger.(StatsLogger)
+	if !ok {
+		return
+	}
+	normalizedSQL, hash := Fingerprint(q)
+	statsLogger.LogQueryStats(QueryStats{
+		Query:       normalizedSQL,
+		Fingerprint: hash,
+		RowCount:    rowCount,
+		Elapsed:     elapsed,
+		Err:         err,
+	})
+}
+
+// appendCollapsedList writes a non-empty list of values as a single
+// placeholder, returning false if the value is not such a list. A RowValue
+// containing only values is written as '(?)' and a list of RowValues as its
+// first RowValue.
+func appendCollapsedList(buf *strings.Builder, args *[]interface{}, value interface{}) bool {
+	switch v := value.(type) {
+	case RowValues:
+		if len(v) == 0 {
+			return false
+		}
+		if !appendCollapsedList(buf, args, v[0]) {
+			v[0].AppendSQL(buf, args, nil)
+		}
+		return true
+	case RowValue:
+		if len(v) == 0 {
+			return false
+		}
+		for _, value := range v {
+			switch value.(type) {
+			case Field, Query, RowValue, RowValues:
+				return false
+			}
+		}
+		buf.WriteString("(?)")
+		return true
+	case uuidList:
+		if len(v) == 0 {
+			return false
+		}
+		buf.WriteString("?")
+		return true
+	case nil:
+		return false
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 || rv.Len() == 0 {
+		return false
+	}
+	buf.WriteString("?")
+	return true
+}
+
+// fingerprintFormat returns the format of a CustomPredicate with 'x = ANY(?)'
+// and 'x <> ALL(?)' array parameters rewritten as 'x IN (?)' and
+// 'x NOT IN (?)', so that the fingerprint does not depend on whether a list
+// was long enough to be bound as an array parameter.
+func fingerprintFormat(p CustomPredicate) string {
+	if len(p.Values) != 2 {
+		return p.Format
+	}
+	if _, ok := p.Values[1].(ArrayParameter); !ok {
+		return p.Format
+	}
+	switch p.Format {
+	case "? = ANY(?)":
+		return "? IN (?)"
+	case "? <> ALL(?)":
+		return "? NOT IN (?)"
+	}
+	return p.Format
+}
@@ -0,0 +1,59 @@
+package sq
+
+import "strings"
+
+// GroupingSets represents the 'GROUPING SETS ((a, b), (a), ())' GROUP BY
+// element, which groups the rows by each set of fields in turn. An empty set
+// represents the grand total.
+//
+//	From(tbl).GroupBy(GroupingSets(Fields{tbl.A, tbl.B}, Fields{tbl.A}, Fields{}))
+func GroupingSets(sets ...Fields) CustomField {
+	buf := &strings.Builder{}
+	values := make([]interface{}, 0, len(sets))
+	buf.WriteString("GROUPING SETS (")
+	for i, set := range sets {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if len(set) == 0 {
+			buf.WriteString("()")
+			continue
+		}
+		buf.WriteString("(?)")
+		values = append(values, set)
+	}
+	buf.WriteString(")")
+	return CustomField{
+		Format: buf.String(),
+		Values: values,
+	}
+}
+
+// Rollup represents the 'ROLLUP (a, b)' GROUP BY element, which is shorthand
+// for the grouping sets (a, b), (a) and ().
+func Rollup(fields ...Field) CustomField {
+	return CustomField{
+		Format: "ROLLUP (?)",
+		Values: []interface{}{Fields(fields)},
+	}
+}
+
+// Cube represents the 'CUBE (a, b)' GROUP BY element, which is shorthand for
+// every subset of the fields as a grouping set i.e. (a, b), (a), (b) and ().
+func Cube(fields ...Field) CustomField {
+	return CustomField{
+		Format: "CUBE (?)",
+		Values: []interface{}{Fields(fields)},
+	}
+}
+
+// Grouping represents the GROUPING() function, which returns a bit mask of
+// the fields that are not part of the current grouping set. It can be used to
+// tell subtotal rows apart from rows where the field is actually NULL.
+func Grouping(fields ...Field) NumberField {
+	format := "GROUPING(?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{Fields(fields)},
+	}
+}
@@ -0,0 +1,50 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGrouping(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		wantQuery   string
+	}
+	ur := USER_ROLES().As("ur")
+	tests := []TT{
+		{"GroupingSets", GroupingSets(Fields{ur.COHORT, ur.ROLE}, Fields{ur.COHORT}, Fields{}), "GROUPING SETS ((ur.cohort, ur.role), (ur.cohort), ())"},
+		{"Rollup", Rollup(ur.COHORT, ur.ROLE), "ROLLUP (ur.cohort, ur.role)"},
+		{"Cube", Cube(ur.COHORT, ur.ROLE), "CUBE (ur.cohort, ur.role)"},
+		{"Grouping", Grouping(ur.COHORT, ur.ROLE), "GROUPING(ur.cohort, ur.role)"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(0, len(args))
+		})
+	}
+}
+
+func TestGrouping_SelectQuery(t *testing.T) {
+	is := is.New(t)
+	ur := USER_ROLES().As("ur")
+	gotQuery, gotArgs := From(ur).
+		Where(ur.DELETED_AT.IsNull()).
+		GroupBy(ur.COHORT, Rollup(ur.ROLE)).
+		Having(Count().GtInt(1)).
+		Select(ur.COHORT, ur.ROLE, Grouping(ur.ROLE).As("is_subtotal"), Count()).
+		ToSQL()
+	is.Equal("SELECT ur.cohort, ur.role, GROUPING(ur.role) AS is_subtotal, COUNT(*)"+
+		" FROM public.user_roles AS ur WHERE ur.deleted_at IS NULL"+
+		" GROUP BY ur.cohort, ROLLUP (ur.role) HAVING COUNT(*) > $1", gotQuery)
+	is.Equal([]interface{}{1}, gotArgs)
+}
@@ -15,6 +15,7 @@ import (
 // InsertQuery represents an INSERT query.
 type InsertQuery struct {
 	nested bool
+	format sqlFormat
 	// WITH
 	CTEs []CTE
 	// INSERT INTO
@@ -38,6 +39,9 @@ type InsertQuery struct {
 	ColumnMapper func(*Column)
 	RowMapper    func(*Row)
 	Accumulator  func()
+	// AutoValidate makes Fetch and Exec call Validate before running the
+	// query.
+	AutoValidate bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -71,7 +75,7 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// WITH
 	if !q.nested && q.SelectQuery != nil {
-		appendCTEs(buf, args, q.CTEs, q.SelectQuery.FromTable, q.SelectQuery.JoinTables)
+		appendCTEs(buf, args, q.CTEs, q.SelectQuery.FromTable, q.SelectQuery.JoinTables, q.format)
 	}
 	// INSERT INTO
 	buf.WriteString("INSERT INTO ")
@@ -97,18 +101,20 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	// VALUES/SELECT
 	switch {
 	case len(q.RowValues) > 0:
-		buf.WriteString(" VALUES ")
+		q.format.newline(buf)
+		buf.WriteString("VALUES ")
 		q.RowValues.AppendSQL(buf, args, nil)
 	case q.SelectQuery != nil:
-		buf.WriteString(" ")
+		q.format.newline(buf)
 		q.SelectQuery.nested = true
-		q.SelectQuery.AppendSQL(buf, args, nil)
+		q.format.nest(*q.SelectQuery).AppendSQL(buf, args, nil)
 	}
 	// ON CONFLICT
 	var noConflict bool
 	switch {
 	case q.HandleConflict:
-		buf.WriteString(" ON CONFLICT")
+		q.format.newline(buf)
+		buf.WriteString("ON CONFLICT")
 		switch {
 		case q.ConflictConstraint != "":
 			buf.WriteString(" ON CONSTRAINT ")
@@ -118,9 +124,10 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 			q.ConflictFields.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
 			buf.WriteString(")")
 			if len(q.ConflictPredicate.Predicates) > 0 {
-				buf.WriteString(" WHERE ")
+				q.format.newline(buf)
+				buf.WriteString("WHERE ")
 				q.ConflictPredicate.toplevel = true
-				q.ConflictPredicate.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
+				q.format.appendPredicate(buf, args, q.ConflictPredicate, excludedTableQualifiers)
 			}
 		}
 	default:
@@ -130,19 +137,23 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	case noConflict:
 		break
 	case len(q.Resolution) > 0:
-		buf.WriteString(" DO UPDATE SET ")
+		q.format.newline(buf)
+		buf.WriteString("DO UPDATE SET ")
 		q.Resolution.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
 		if len(q.ResolutionPredicate.Predicates) > 0 {
-			buf.WriteString(" WHERE ")
+			q.format.newline(buf)
+			buf.WriteString("WHERE ")
 			q.ResolutionPredicate.toplevel = true
-			q.ResolutionPredicate.AppendSQLExclude(buf, args, nil, nil)
+			q.format.appendPredicate(buf, args, q.ResolutionPredicate, nil)
 		}
 	default:
-		buf.WriteString(" DO NOTHING")
+		q.format.newline(buf)
+		buf.WriteString("DO NOTHING")
 	}
 	// RETURNING
 	if len(q.ReturningFields) > 0 {
-		buf.WriteString(" RETURNING ")
+		q.format.newline(buf)
+		buf.WriteString("RETURNING ")
 		q.ReturningFields.AppendSQLExcludeWithAlias(buf, args, nil, nil)
 	}
 	if !q.nested {
@@ -150,15 +161,21 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 		buf.Reset()
 		questionToDollarPlaceholders(buf, query)
 		if q.Log != nil {
+			logQuery, logArgs := buf.String(), *args
+			interpolate := questionInterpolate
+			if Lpretty&q.LogFlag != 0 && !q.format.pretty {
+				logQuery, logArgs = prettyLogQuery(q)
+				query, interpolate = logQuery, dollarInterpolate
+			}
 			var logOutput string
 			switch {
 			case Lstats&q.LogFlag != 0:
-				logOutput = "\n----[ Executing query ]----\n" + buf.String() + " " + fmt.Sprint(*args) +
-					"\n----[ with bind values ]----\n" + questionInterpolate(query, *args...)
+				logOutput = "\n----[ Executing query ]----\n" + logQuery + " " + fmt.Sprint(logArgs) +
+					"\n----[ with bind values ]----\n" + interpolate(query, logArgs...)
 			case Linterpolate&q.LogFlag != 0:
-				logOutput = questionInterpolate(query, *args...)
+				logOutput = interpolate(query, logArgs...)
 			default:
-				logOutput = buf.String() + " " + fmt.Sprint(*args)
+				logOutput = logQuery + " " + fmt.Sprint(logArgs)
 			}
 			switch q.Log.(type) {
 			case *log.Logger:
@@ -335,6 +352,7 @@ func (q InsertQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, int64(rowcount), elapsed, err)
 		if Lresults&q.LogFlag != 0 && rowcount > 5 {
 			logBuf.WriteString("\n...")
 		}
@@ -357,10 +375,16 @@ func (q InsertQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.ReturningFields = r.fields
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return err
+		}
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
 	} else {
@@ -435,6 +459,11 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 		db = q.DB
 	}
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return rowsAffected, err
+		}
+	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
 	defer func() {
@@ -451,6 +480,7 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, rowsAffected, elapsed, err)
 		if Lstats&q.LogFlag != 0 && ErowsAffected&flag != 0 {
 			logBuf.WriteString("\n(Inserted ")
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
@@ -472,6 +502,7 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
@@ -0,0 +1,312 @@
+package sq
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interpolate replaces the dollar $1, $2 etc placeholders in a query with
+// their args, returning SQL that can be run as-is in psql. Strings are escaped
+// (assuming standard_conforming_strings is on, the default since Postgres
+// 9.1), []byte is rendered as bytea, slices as arrays and maps and structs as
+// JSON. Times are rendered in UTC, use InterpolateIn for a different session
+// time zone. Placeholders inside string literals, quoted identifiers and
+// comments are left alone.
+//
+// Interpolate returns an error if a placeholder has no matching arg or an arg
+// cannot be represented in SQL.
+func Interpolate(query string, args []interface{}) (string, error) {
+	return InterpolateIn(time.UTC, query, args)
+}
+
+// InterpolateIn is like Interpolate, but renders times in the loc time zone.
+// loc should be the time zone of the session that the SQL will be run in so
+// that timestamps without time zone keep their meaning.
+func InterpolateIn(loc *time.Location, query string, args []interface{}) (string, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	buf := &strings.Builder{}
+	for i := 0; i < len(query); {
+		n := skipQuoted(query, i)
+		if n > i {
+			buf.WriteString(query[i:n])
+			i = n
+			continue
+		}
+		if query[i] != '$' || i+1 == len(query) || !isDigit(query[i+1]) {
+			buf.WriteByte(query[i])
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(query) && isDigit(query[j]) {
+			j++
+		}
+		index, err := strconv.Atoi(query[i+1 : j])
+		if err != nil || index < 1 || index > len(args) {
+			return "", fmt.Errorf("sq: placeholder %s has no matching arg (%d args)", query[i:j], len(args))
+		}
+		err = interpolateValue(buf, loc, args[index-1])
+		if err != nil {
+			return "", fmt.Errorf("sq: placeholder %s: %w", query[i:j], err)
+		}
+		i = j
+	}
+	return buf.String(), nil
+}
+
+// ToSQLInterpolated returns the SelectQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q SelectQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the InsertQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q InsertQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the UpdateQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q UpdateQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the DeleteQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q DeleteQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the VariadicQuery as SQL with its args
+// interpolated. See Interpolate.
+func (vq VariadicQuery) ToSQLInterpolated() (string, error) {
+	query, args := vq.ToSQL()
+	return Interpolate(query, args)
+}
+
+// ToSQLInterpolated returns the ValuesQuery as SQL with its args interpolated.
+// See Interpolate.
+func (q ValuesQuery) ToSQLInterpolated() (string, error) {
+	query, args := q.ToSQL()
+	return Interpolate(query, args)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// skipQuoted returns the index just past the string literal, quoted
+// identifier, dollar quoted string or comment starting at query[i], or i if
+// there is none.
+func skipQuoted(query string, i int) int {
+	rest := query[i:]
+	switch {
+	case strings.HasPrefix(rest, "--"):
+		if n := strings.IndexByte(rest, '\n'); n >= 0 {
+			return i + n + 1
+		}
+		return len(query)
+	case strings.HasPrefix(rest, "/*"):
+		if n := strings.Index(rest[2:], "*/"); n >= 0 {
+			return i + 2 + n + 2
+		}
+		return len(query)
+	case rest[0] == '\'':
+		// E'...' strings allow backslash escapes
+		backslash := i > 0 && (query[i-1] == 'E' || query[i-1] == 'e')
+		for j := i + 1; j < len(query); j++ {
+			switch {
+			case backslash && query[j] == '\\':
+				j++
+			case query[j] == '\'':
+				if j+1 < len(query) && query[j+1] == '\'' {
+					j++
+					continue
+				}
+				return j + 1
+			}
+		}
+		return len(query)
+	case rest[0] == '"':
+		for j := i + 1; j < len(query); j++ {
+			if query[j] == '"' {
+				if j+1 < len(query) && query[j+1] == '"' {
+					j++
+					continue
+				}
+				return j + 1
+			}
+		}
+		return len(query)
+	case rest[0] == '$':
+		// $tag$ ... $tag$
+		n := strings.IndexByte(rest[1:], '$')
+		if n < 0 {
+			return i
+		}
+		tag := rest[:n+2]
+		for k, c := range tag[1 : len(tag)-1] {
+			if !(c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || k > 0 && '0' <= c && c <= '9') {
+				return i
+			}
+		}
+		if m := strings.Index(rest[len(tag):], tag); m >= 0 {
+			return i + len(tag) + m + len(tag)
+		}
+		return len(query)
+	}
+	return i
+}
+
+// interpolateValue writes the SQL literal of a value into the buffer.
+func interpolateValue(buf *strings.Builder, loc *time.Location, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("NULL")
+		return nil
+	case json.RawMessage:
+		if v == nil {
+			buf.WriteString("NULL")
+			return nil
+		}
+		return quoteString(buf, string(v))
+	case []byte:
+		if v == nil {
+			buf.WriteString("NULL")
+			return nil
+		}
+		buf.WriteString(`'\x`)
+		buf.WriteString(hex.EncodeToString(v))
+		buf.WriteString("'::BYTEA")
+		return nil
+	case time.Time:
+		buf.WriteString("'")
+		buf.WriteString(v.In(loc).Format("2006-01-02 15:04:05.999999-07:00"))
+		buf.WriteString("'")
+		return nil
+	case [16]byte:
+		buf.WriteString("'")
+		buf.WriteString(formatUUID(v))
+		buf.WriteString("'")
+		return nil
+	case driver.Valuer:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		Interface, err := v.Value()
+		if err != nil {
+			return err
+		}
+		if _, ok := Interface.(driver.Valuer); ok {
+			return fmt.Errorf("%T.Value returned another driver.Valuer", v)
+		}
+		return interpolateValue(buf, loc, Interface)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		return interpolateValue(buf, loc, rv.Elem().Interface())
+	case reflect.Bool:
+		if rv.Bool() {
+			buf.WriteString("TRUE")
+		} else {
+			buf.WriteString("FALSE")
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeNumber(buf, strconv.FormatInt(rv.Int(), 10))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		buf.WriteString(strconv.FormatUint(rv.Uint(), 10))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		switch {
+		case math.IsNaN(f):
+			buf.WriteString("'NaN'::FLOAT")
+		case math.IsInf(f, 1):
+			buf.WriteString("'Infinity'::FLOAT")
+		case math.IsInf(f, -1):
+			buf.WriteString("'-Infinity'::FLOAT")
+		default:
+			writeNumber(buf, strconv.FormatFloat(f, 'g', -1, rv.Type().Bits()))
+		}
+		return nil
+	case reflect.String:
+		return quoteString(buf, rv.String())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return interpolateValue(buf, loc, rv.Bytes())
+		}
+		if rv.IsNil() {
+			buf.WriteString("NULL")
+			return nil
+		}
+		if rv.Len() == 0 {
+			buf.WriteString("'{}'")
+			return nil
+		}
+		buf.WriteString("ARRAY[")
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			err := interpolateValue(buf, loc, rv.Index(i).Interface())
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteString("]")
+		return nil
+	case reflect.Map, reflect.Struct:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return quoteString(buf, string(b))
+	}
+	return fmt.Errorf("cannot interpolate value of type %T", value)
+}
+
+// writeNumber writes a number into the buffer, wrapping negative numbers in
+// parentheses so that a preceding minus sign cannot turn them into a comment
+// i.e. '-$1' must not become '--1'.
+func writeNumber(buf *strings.Builder, s string) {
+	if strings.HasPrefix(s, "-") {
+		buf.WriteString("(" + s + ")")
+		return
+	}
+	buf.WriteString(s)
+}
+
+// quoteString writes a string literal into the buffer, doubling any single
+// quotes.
+func quoteString(buf *strings.Builder, s string) error {
+	if strings.IndexByte(s, 0) >= 0 {
+		return fmt.Errorf("string %q contains a NUL byte", s)
+	}
+	buf.WriteString("'")
+	buf.WriteString(strings.Replace(s, "'", "''", -1))
+	buf.WriteString("'")
+	return nil
+}
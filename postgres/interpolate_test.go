@@ -0,0 +1,116 @@
+package sq
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/matryer/is"
+)
+
+func TestInterpolate(t *testing.T) {
+	type TT struct {
+		description string
+		query       string
+		args        []interface{}
+		wantQuery   string
+	}
+	sgt := time.FixedZone("SGT", 8*60*60)
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 600000000, sgt)
+	id := [16]byte{0x5e, 0x0e, 0x1d, 0x3a, 0x8e, 0x2b, 0x4a, 0xc4, 0x9f, 0x3e, 0x2b, 0x1c, 0x4d, 0x6f, 0x7a, 0x80}
+	name := "bob"
+	tests := []TT{
+		{"nil", "SELECT $1", []interface{}{nil}, "SELECT NULL"},
+		{"bool", "SELECT $1, $2", []interface{}{true, false}, "SELECT TRUE, FALSE"},
+		{"numbers", "SELECT $1, $2, $3", []interface{}{-1, uint8(2), 1.5}, "SELECT (-1), 2, 1.5"},
+		{"negative numbers", "SELECT (-$1), -$2", []interface{}{-5, -2.5}, "SELECT (-(-5)), -(-2.5)"},
+		{"NaN", "SELECT $1, $2", []interface{}{math.NaN(), math.Inf(-1)}, "SELECT 'NaN'::FLOAT, '-Infinity'::FLOAT"},
+		{"string", "SELECT $1", []interface{}{"it's"}, "SELECT 'it''s'"},
+		{"string injection", "SELECT $1", []interface{}{`'; DROP TABLE users; --`}, `SELECT '''; DROP TABLE users; --'`},
+		{"backslash", "SELECT $1", []interface{}{`C:\`}, `SELECT 'C:\'`},
+		{"bytes", "SELECT $1", []interface{}{[]byte{0xde, 0xad}}, `SELECT '\xdead'::BYTEA`},
+		{"nil bytes", "SELECT $1", []interface{}{[]byte(nil)}, "SELECT NULL"},
+		{"time", "SELECT $1", []interface{}{ts}, "SELECT '2020-01-01 19:04:05.6+00:00'"},
+		{"uuid", "SELECT $1::UUID", []interface{}{id}, "SELECT '5e0e1d3a-8e2b-4ac4-9f3e-2b1c4d6f7a80'::UUID"},
+		{"array", "SELECT $1, $2", []interface{}{[]string{"a", "b'c"}, []int{}}, "SELECT ARRAY['a', 'b''c'], '{}'"},
+		{"pq array", "SELECT $1", []interface{}{pq.Int64Array{1, 2}}, "SELECT '{1,2}'"},
+		{"JSON", "SELECT $1, $2", []interface{}{map[string]string{"a": "it's"}, json.RawMessage(`[1]`)}, `SELECT '{"a":"it''s"}', '[1]'`},
+		{"valuer", "SELECT $1, $2", []interface{}{sql.NullString{}, sql.NullInt64{Int64: 5, Valid: true}}, "SELECT NULL, 5"},
+		{"pointer", "SELECT $1, $2", []interface{}{&name, (*string)(nil)}, "SELECT 'bob', NULL"},
+		{"repeated placeholder", "SELECT $1, $1", []interface{}{1}, "SELECT 1, 1"},
+		{"double digit placeholders", "SELECT $10, $1", []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, "SELECT 10, 1"},
+		{
+			"placeholders in quotes and comments",
+			`SELECT '$1', "$1", $$ $1 $$, $a$ $1 $a$, E'\' $1', $1 -- $1` + "\n/* $1 */",
+			[]interface{}{1},
+			`SELECT '$1', "$1", $$ $1 $$, $a$ $1 $a$, E'\' $1', 1 -- $1` + "\n/* $1 */",
+		},
+		{"jsonb operator", "SELECT data ? 'key' FROM t WHERE id = $1", []interface{}{1}, "SELECT data ? 'key' FROM t WHERE id = 1"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, err := Interpolate(tt.query, tt.args)
+			is.NoErr(err)
+			is.Equal(tt.wantQuery, gotQuery)
+		})
+	}
+}
+
+func TestInterpolate_Errors(t *testing.T) {
+	type TT struct {
+		description string
+		query       string
+		args        []interface{}
+		wantErr     string
+	}
+	tests := []TT{
+		{"missing arg", "SELECT $1, $2", []interface{}{1}, "sq: placeholder $2 has no matching arg (1 args)"},
+		{"unsupported type", "SELECT $1", []interface{}{make(chan int)}, "sq: placeholder $1: cannot interpolate value of type chan int"},
+		{"NUL byte", "SELECT $1", []interface{}{"a\x00"}, `sq: placeholder $1: string "a\x00" contains a NUL byte`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			_, err := Interpolate(tt.query, tt.args)
+			is.True(err != nil)
+			is.Equal(tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestInterpolateIn(t *testing.T) {
+	is := is.New(t)
+	sgt := time.FixedZone("SGT", 8*60*60)
+	ts := time.Date(2020, 1, 1, 19, 4, 5, 0, time.UTC)
+	gotQuery, err := InterpolateIn(sgt, "SELECT $1", []interface{}{ts})
+	is.NoErr(err)
+	is.Equal("SELECT '2020-01-02 03:04:05+08:00'", gotQuery)
+}
+
+func TestToSQLInterpolated(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	gotQuery, err := From(u).
+		Where(u.DISPLAYNAME.EqString("O'Brien"), u.USER_ID.In([]int{1, 2})).
+		Select(u.USER_ID).
+		ToSQLInterpolated()
+	is.NoErr(err)
+	is.Equal("SELECT u.user_id FROM public.users AS u WHERE u.displayname = 'O''Brien' AND u.user_id IN (1, 2)", gotQuery)
+	gotQuery, err = InsertInto(u).Columns(u.EMAIL, u.PASSWORD).Values("bob@email.com", nil).ToSQLInterpolated()
+	is.NoErr(err)
+	is.Equal("INSERT INTO public.users AS u (email, password) VALUES ('bob@email.com', NULL)", gotQuery)
+	gotQuery, err = Union(
+		Select(Int(1)),
+		Select(String("a\nb")),
+	).ToSQLInterpolated()
+	is.NoErr(err)
+	is.Equal("SELECT 1 UNION SELECT 'a\nb'", gotQuery)
+}
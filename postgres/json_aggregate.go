@@ -0,0 +1,253 @@
+package sq
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONAggregate is a Field that aggregates the rows of a child query into a
+// JSON array i.e. '(SELECT json_agg(json_build_object(...)) FROM ...)'. The
+// fields of each JSON object are taken from the child query's mapper
+// function, and Row.ScanJSONAgg decodes the JSON array back by calling the
+// same mapper function on each element.
+//
+// This lets a parent query load its children in a single query instead of
+// running one query per parent row:
+//
+//	var session Session
+//	var sessions []Session
+//	agg := JSONAgg(
+//	    From(s).Where(s.USER_ID.Eq(u.USER_ID)).Selectx(
+//	        session.RowMapper(s),
+//	        func() { sessions = append(sessions, session) },
+//	    ),
+//	)
+//	err := From(u).Selectx(func(row *Row) {
+//	    sessions = nil
+//	    user.UserID = row.Int(u.USER_ID)
+//	    row.ScanJSONAgg(agg)
+//	    user.Sessions = sessions
+//	}, accumulator).Fetch(db)
+type JSONAggregate struct {
+	alias string
+	query SelectQuery
+}
+
+// JSONAgg creates a new JSONAggregate from a SelectQuery. The SelectQuery's
+// mapper function determines the fields of each JSON object, its SelectFields
+// are ignored. If the SelectQuery has an ORDER BY it is moved into the
+// json_agg() call so that the order of the JSON array is preserved.
+func JSONAgg(query SelectQuery) JSONAggregate {
+	return JSONAggregate{
+		query: query,
+	}
+}
+
+// AppendSQLExclude marshals the JSONAggregate into a buffer and an args slice.
+func (agg JSONAggregate) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	r := &Row{}
+	if agg.query.RowMapper != nil {
+		agg.query.RowMapper(r)
+	}
+	object := jsonBuildObject(r.fields)
+	q := agg.query
+	q.RowMapper = nil
+	q.Accumulator = nil
+	if q.LimitValue == nil && q.OffsetValue == nil {
+		// (SELECT COALESCE(json_agg(json_build_object(...) ORDER BY ...), '[]') FROM ...)
+		if len(q.OrderByFields) > 0 {
+			q.SelectFields = Fields{Fieldf("COALESCE(json_agg(? ORDER BY ?), '[]')", object, q.OrderByFields)}
+			q.OrderByFields = nil
+		} else {
+			q.SelectFields = Fields{Fieldf("COALESCE(json_agg(?), '[]')", object)}
+		}
+		buf.WriteString("(")
+		q.NestThis().AppendSQL(buf, args, nil)
+		buf.WriteString(")")
+		return
+	}
+	// LIMIT and OFFSET apply to the aggregated row instead of the child rows,
+	// so the child query has to be wrapped in a derived table.
+	q.SelectFields = Fields{object.As("json_row")}
+	buf.WriteString("(SELECT COALESCE(json_agg(json_rows.json_row), '[]') FROM (")
+	q.NestThis().AppendSQL(buf, args, nil)
+	buf.WriteString(") AS json_rows)")
+}
+
+// jsonBuildObject builds a json_build_object() call using the index of each
+// field as its key.
+func jsonBuildObject(fields []Field) CustomField {
+	buf := &strings.Builder{}
+	buf.WriteString("json_build_object(")
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("'" + strconv.Itoa(i) + "', ?")
+		values[i] = field
+	}
+	buf.WriteString(")")
+	return CustomField{
+		Format: buf.String(),
+		Values: values,
+	}
+}
+
+// As returns a new JSONAggregate with the new alias i.e. 'field AS Alias'.
+func (agg JSONAggregate) As(alias string) JSONAggregate {
+	agg.alias = alias
+	return agg
+}
+
+// GetAlias implements the Field interface. It returns the alias of the
+// JSONAggregate.
+func (agg JSONAggregate) GetAlias() string {
+	return agg.alias
+}
+
+// GetName implements the Field interface. It returns the name of the
+// JSONAggregate.
+func (agg JSONAggregate) GetName() string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	agg.AppendSQLExclude(buf, &args, nil, nil)
+	return buf.String()
+}
+
+// scan decodes a JSON array produced by the JSONAggregate, calling the
+// JSONAggregate query's mapper function (and accumulator function) on each
+// element.
+func (agg JSONAggregate) scan(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			default:
+				err = fmt.Errorf("%#v", r)
+			}
+		}
+	}()
+	if agg.query.RowMapper == nil {
+		return fmt.Errorf("cannot scan a JSONAggregate without a mapper")
+	}
+	var objects []map[string]json.RawMessage
+	err = json.Unmarshal(data, &objects)
+	if err != nil {
+		return err
+	}
+	r := &Row{}
+	agg.query.RowMapper(r)
+	r.jsonValues = make([]json.RawMessage, len(r.fields))
+	for _, object := range objects {
+		for i := range r.fields {
+			r.jsonValues[i] = object[strconv.Itoa(i)]
+			switch r.dest[i].(type) {
+			case *sql.NullBool, *sql.NullFloat64, *sql.NullInt32, *sql.NullInt64, *sql.NullString, *sql.NullTime:
+				err = scanJSONValue(r.dest[i], r.fields[i], r.jsonValues[i])
+				if err != nil {
+					return fmt.Errorf("%d) %s: %w", i, r.fields[i].GetName(), err)
+				}
+			}
+		}
+		r.index = 0
+		agg.query.RowMapper(r)
+		if agg.query.Accumulator == nil {
+			break
+		}
+		agg.query.Accumulator()
+	}
+	return nil
+}
+
+// jsonTimeLayouts are the layouts that a date or time value may take when it
+// is rendered as JSON.
+var jsonTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999Z07:00",
+	"15:04:05.999999999",
+}
+
+// scanJSONValue scans a raw JSON value into dest. JSON strings are parsed
+// into time.Time if dest is a *sql.NullTime and hex decoded if field is a
+// BinaryField, JSON numbers become int64 or float64 and JSON arrays or objects
+// are passed along as []byte.
+func scanJSONValue(dest interface{}, field Field, raw json.RawMessage) error {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if len(raw) > 0 {
+		err := decoder.Decode(&value)
+		if err != nil {
+			return err
+		}
+	}
+	switch v := value.(type) {
+	case json.Number:
+		if num, err := v.Int64(); err == nil {
+			value = num
+		} else {
+			num, err := v.Float64()
+			if err != nil {
+				return err
+			}
+			value = num
+		}
+	case string:
+		if _, ok := field.(BinaryField); ok {
+			b, err := hex.DecodeString(strings.TrimPrefix(v, `\x`))
+			if err != nil {
+				return err
+			}
+			value = b
+			break
+		}
+		if _, ok := dest.(*sql.NullTime); ok {
+			var t time.Time
+			var err error
+			for _, layout := range jsonTimeLayouts {
+				t, err = time.Parse(layout, v)
+				if err == nil {
+					break
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("%q is not a valid time", v)
+			}
+			value = t
+		}
+	case []interface{}, map[string]interface{}:
+		value = []byte(raw)
+	}
+	switch ptr := dest.(type) {
+	case sql.Scanner:
+		return ptr.Scan(value)
+	case *[]byte:
+		switch v := value.(type) {
+		case nil:
+			*ptr = nil
+		case []byte:
+			*ptr = v
+		case string:
+			*ptr = []byte(v)
+		default:
+			*ptr = []byte(raw)
+		}
+		return nil
+	}
+	if value == nil {
+		return nil
+	}
+	return json.Unmarshal(raw, dest)
+}
@@ -0,0 +1,150 @@
+package sq
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestJSONAggregate_AppendSQLExclude(t *testing.T) {
+	type TT struct {
+		description string
+		f           JSONAggregate
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	s := SESSIONS().As("s")
+	var session struct {
+		Hash      string
+		CreatedAt time.Time
+	}
+	mapper := func(row *Row) {
+		session.Hash = row.String(s.HASH)
+		session.CreatedAt = row.Time(s.CREATED_AT)
+	}
+	tests := []TT{
+		{
+			"basic",
+			JSONAgg(From(s).Where(s.USER_ID.Eq(u.USER_ID)).SelectRowx(mapper)),
+			"(SELECT COALESCE(json_agg(json_build_object('0', s.hash, '1', s.created_at)), '[]')" +
+				" FROM public.sessions AS s WHERE s.user_id = u.user_id)",
+			nil,
+		},
+		{
+			"ORDER BY is moved into json_agg",
+			JSONAgg(From(s).Where(s.USER_ID.EqInt(1)).OrderBy(s.CREATED_AT.Desc()).SelectRowx(mapper)),
+			"(SELECT COALESCE(json_agg(json_build_object('0', s.hash, '1', s.created_at) ORDER BY s.created_at DESC), '[]')" +
+				" FROM public.sessions AS s WHERE s.user_id = ?)",
+			[]interface{}{1},
+		},
+		{
+			"LIMIT wraps the query in a derived table",
+			JSONAgg(From(s).OrderBy(s.CREATED_AT).Limit(5).SelectRowx(mapper)),
+			"(SELECT COALESCE(json_agg(json_rows.json_row), '[]') FROM" +
+				" (SELECT json_build_object('0', s.hash, '1', s.created_at) AS json_row" +
+				" FROM public.sessions AS s ORDER BY s.created_at LIMIT ?) AS json_rows)",
+			[]interface{}{int64(5)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			var _ Field = tt.f
+			tt.f.AppendSQLExclude(buf, &args, nil, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestJSONAggregate_scan(t *testing.T) {
+	is := is.New(t)
+	m := MEDIA()
+	type Media struct {
+		Name      string
+		Valid     bool
+		Data      []byte
+		CreatedAt time.Time
+		DeletedAt sql.NullTime
+		Size      int
+	}
+	var media Media
+	var medias []Media
+	agg := JSONAgg(From(m).Selectx(func(row *Row) {
+		media.Name = row.String(m.NAME)
+		media.Valid = row.StringValid(m.DESCRIPTION)
+		row.ScanInto(&media.Data, m.DATA)
+		media.CreatedAt = row.Time(m.CREATED_AT)
+		media.DeletedAt = row.NullTime(m.DELETED_AT)
+		media.Size = row.Int(NumberFieldf("length(?)", m.DATA))
+	}, func() {
+		medias = append(medias, media)
+	}))
+	err := agg.scan([]byte(`[` +
+		`{"0": "a.png", "1": null, "2": "\\x0102ff", "3": "2020-06-24T16:33:10.123456+08:00", "4": null, "5": 3},` +
+		`{"0": "b.png", "1": "desc", "2": null, "3": "2020-06-25T08:00:00", "4": "2020-06-26", "5": 0}` +
+		`]`))
+	is.NoErr(err)
+	is.Equal(2, len(medias))
+	is.Equal("a.png", medias[0].Name)
+	is.Equal(false, medias[0].Valid)
+	is.Equal([]byte{1, 2, 255}, medias[0].Data)
+	is.True(medias[0].CreatedAt.Equal(time.Date(2020, 6, 24, 8, 33, 10, 123456000, time.UTC)))
+	is.Equal(false, medias[0].DeletedAt.Valid)
+	is.Equal(3, medias[0].Size)
+	is.Equal("b.png", medias[1].Name)
+	is.Equal(true, medias[1].Valid)
+	is.Equal([]byte(nil), medias[1].Data)
+	is.Equal(time.Date(2020, 6, 25, 8, 0, 0, 0, time.UTC), medias[1].CreatedAt)
+	is.Equal(sql.NullTime{Time: time.Date(2020, 6, 26, 0, 0, 0, 0, time.UTC), Valid: true}, medias[1].DeletedAt)
+
+	// Invalid JSON
+	err = agg.scan([]byte(`{`))
+	is.True(err != nil)
+
+	// Invalid time
+	err = agg.scan([]byte(`[{"3": "yesterday"}]`))
+	is.True(err != nil)
+}
+
+func TestJSONAggregate_Fetch(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "JSONAggregate_Fetch")
+	is.NoErr(err)
+	defer db.Close()
+	u, s := USERS().As("u"), SESSIONS().As("s")
+	type User struct {
+		UserID int
+		Hashes []string
+	}
+	var hash string
+	var hashes []string
+	agg := JSONAgg(From(s).Where(s.USER_ID.Eq(u.USER_ID)).OrderBy(s.HASH).Selectx(func(row *Row) {
+		hash = row.String(s.HASH)
+	}, func() {
+		hashes = append(hashes, hash)
+	}))
+	var user User
+	var users []User
+	err = From(u).OrderBy(u.USER_ID).Limit(5).Selectx(func(row *Row) {
+		hashes = nil
+		user.UserID = row.Int(u.USER_ID)
+		row.ScanJSONAgg(agg)
+		user.Hashes = hashes
+	}, func() {
+		users = append(users, user)
+	}).Fetch(db)
+	is.NoErr(err)
+	is.Equal(5, len(users))
+}
@@ -0,0 +1,70 @@
+package sq
+
+// Add returns a new NumberField representing '(X + Y)'. It accepts a
+// NumberField or a Go number.
+func (f NumberField) Add(v interface{}) NumberField {
+	return NumberFieldf("(? + ?)", f, v)
+}
+
+// Sub returns a new NumberField representing '(X - Y)'. It accepts a
+// NumberField or a Go number.
+func (f NumberField) Sub(v interface{}) NumberField {
+	return NumberFieldf("(? - ?)", f, v)
+}
+
+// Mul returns a new NumberField representing '(X * Y)'. It accepts a
+// NumberField or a Go number.
+func (f NumberField) Mul(v interface{}) NumberField {
+	return NumberFieldf("(? * ?)", f, v)
+}
+
+// Div returns a new NumberField representing '(X / Y)'. It accepts a
+// NumberField or a Go number. Note that dividing two integers in Postgres
+// truncates the result towards zero.
+func (f NumberField) Div(v interface{}) NumberField {
+	return NumberFieldf("(? / ?)", f, v)
+}
+
+// Mod returns a new NumberField representing '(X % Y)'. It accepts a
+// NumberField or a Go number.
+func (f NumberField) Mod(v interface{}) NumberField {
+	return NumberFieldf("(? % ?)", f, v)
+}
+
+// Neg returns a new NumberField representing '(-X)'.
+func (f NumberField) Neg() NumberField {
+	return NumberFieldf("(-?)", f)
+}
+
+// Abs represents the ABS() function.
+func (f NumberField) Abs() NumberField {
+	return NumberFieldf("ABS(?)", f)
+}
+
+// Round represents the ROUND(X, n) function, which rounds to n decimal places.
+// The NumberField is cast to numeric because Postgres has no two argument
+// ROUND() for double precision.
+func (f NumberField) Round(n int) NumberField {
+	return NumberFieldf("ROUND(?::numeric, ?)", f, n)
+}
+
+// Ceil represents the CEIL() function.
+func (f NumberField) Ceil() NumberField {
+	return NumberFieldf("CEIL(?)", f)
+}
+
+// Floor represents the FLOOR() function.
+func (f NumberField) Floor() NumberField {
+	return NumberFieldf("FLOOR(?)", f)
+}
+
+// Power represents the POWER(X, Y) function. It accepts a NumberField or a Go
+// number.
+func (f NumberField) Power(v interface{}) NumberField {
+	return NumberFieldf("POWER(?, ?)", f, v)
+}
+
+// Sqrt represents the SQRT() function.
+func (f NumberField) Sqrt() NumberField {
+	return NumberFieldf("SQRT(?)", f)
+}
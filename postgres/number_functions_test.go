@@ -0,0 +1,75 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestNumberFunctions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	tests := []TT{
+		{"Add", u.USER_ID.Add(1), nil, "(u.user_id + ?)", []interface{}{1}},
+		{"Sub", u.USER_ID.Sub(u.USER_ID), []string{"u"}, "(user_id - user_id)", nil},
+		{"Mul", u.USER_ID.Mul(2.5), nil, "(u.user_id * ?)", []interface{}{2.5}},
+		{"Div", u.USER_ID.Div(Int(7)), nil, "(u.user_id / ?)", []interface{}{7}},
+		{"Mod", u.USER_ID.Mod(3), nil, "(u.user_id % ?)", []interface{}{3}},
+		{"Neg", u.USER_ID.Neg(), nil, "(-u.user_id)", nil},
+		{"Abs", u.USER_ID.Abs(), nil, "ABS(u.user_id)", nil},
+		{"Round", u.USER_ID.Div(3).Round(2), nil, "ROUND((u.user_id / ?)::numeric, ?)", []interface{}{3, 2}},
+		{"Ceil", u.USER_ID.Ceil(), nil, "CEIL(u.user_id)", nil},
+		{"Floor", u.USER_ID.Floor(), nil, "FLOOR(u.user_id)", nil},
+		{"Power", u.USER_ID.Power(2), nil, "POWER(u.user_id, ?)", []interface{}{2}},
+		{"Sqrt", u.USER_ID.Sqrt(), nil, "SQRT(u.user_id)", nil},
+		{
+			"nested",
+			u.USER_ID.Add(1).Mul(u.USER_ID.Sub(2)).Neg(),
+			nil,
+			"(-((u.user_id + ?) * (u.user_id - ?)))",
+			[]interface{}{1, 2},
+		},
+		{"Between", u.USER_ID.Between(Int(1), u.USER_ID.Mul(2)), nil, "u.user_id BETWEEN ? AND (u.user_id * ?)", []interface{}{1, 2}},
+		{"BetweenInt", u.USER_ID.BetweenInt(1, 10), nil, "u.user_id BETWEEN ? AND ?", []interface{}{1, 10}},
+		{"BetweenFloat64", u.USER_ID.BetweenFloat64(0.5, 1.5), nil, "u.user_id BETWEEN ? AND ?", []interface{}{0.5, 1.5}},
+		{"NotBetween", u.USER_ID.NotBetween(Int(1), Int(10)), nil, "u.user_id NOT BETWEEN ? AND ?", []interface{}{1, 10}},
+		{"NotBetweenInt", u.USER_ID.NotBetweenInt(1, 10), nil, "u.user_id NOT BETWEEN ? AND ?", []interface{}{1, 10}},
+		{"NotBetweenFloat64", u.USER_ID.NotBetweenFloat64(0.5, 1.5), nil, "u.user_id NOT BETWEEN ? AND ?", []interface{}{0.5, 1.5}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestNumberFunctions_Queries(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	gotQuery, gotArgs := Update(u).Set(u.USER_ID.Set(u.USER_ID.Add(1))).Where(u.USER_ID.BetweenInt(1, 10)).ToSQL()
+	is.Equal("UPDATE public.users AS u SET user_id = (user_id + $1) WHERE u.user_id BETWEEN $2 AND $3", gotQuery)
+	is.Equal([]interface{}{1, 1, 10}, gotArgs)
+
+	gotQuery, gotArgs = From(u).
+		Where(u.USER_ID.Mod(2).EqInt(0)).
+		OrderBy(u.USER_ID.Mul(-1).Desc()).
+		Select(u.USER_ID.Power(2).As("squared")).
+		ToSQL()
+	is.Equal("SELECT POWER(u.user_id, $1) AS squared FROM public.users AS u"+
+		" WHERE (u.user_id % $2) = $3 ORDER BY (u.user_id * $4) DESC", gotQuery)
+	is.Equal([]interface{}{2, 2, 0, -1}, gotArgs)
+}
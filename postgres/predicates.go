@@ -22,6 +22,9 @@ func (p CustomPredicate) AppendSQLExclude(buf *strings.Builder, args *[]interfac
 	if p.Negative {
 		buf.WriteString("NOT ")
 	}
+	if fingerprinting(args) {
+		p.Format = fingerprintFormat(p)
+	}
 	expandValues(buf, args, excludedTableQualifiers, p.Format, p.Values)
 }
 
@@ -59,7 +62,35 @@ func (p CustomPredicate) GetName() string {
 func Exists(query Query) CustomPredicate {
 	return CustomPredicate{
 		Format: "EXISTS(?)",
-		Values: []interface{}{query},
+		Values: []interface{}{query.NestThis()},
+	}
+}
+
+// NotExists represents the NOT EXISTS() predicate.
+func NotExists(query Query) CustomPredicate {
+	return CustomPredicate{
+		Format: "NOT EXISTS(?)",
+		Values: []interface{}{query.NestThis()},
+	}
+}
+
+// AnyOf represents the 'ANY (subquery)' expression, which is used as the right
+// hand side of a comparison e.g. Eq(tbl.A, AnyOf(query)) or
+// RowValue{tbl.A, tbl.B}.Gt(AnyOf(query)).
+func AnyOf(query Query) CustomField {
+	return CustomField{
+		Format: "ANY (?)",
+		Values: []interface{}{query.NestThis()},
+	}
+}
+
+// AllOf represents the 'ALL (subquery)' expression, which is used as the right
+// hand side of a comparison e.g. Gt(tbl.A, AllOf(query)) or
+// RowValue{tbl.A, tbl.B}.Ne(AllOf(query)).
+func AllOf(query Query) CustomField {
+	return CustomField{
+		Format: "ALL (?)",
+		Values: []interface{}{query.NestThis()},
 	}
 }
 
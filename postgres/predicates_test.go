@@ -109,7 +109,7 @@ func TestExists(t *testing.T) {
 			"basic",
 			Exists(SelectOne().From(u).Where(u.EMAIL.LikeString("%@gmail.com"))),
 			nil,
-			"EXISTS(SELECT 1 FROM public.users WHERE users.email LIKE $1)",
+			"EXISTS(SELECT 1 FROM public.users WHERE users.email LIKE ?)",
 			[]interface{}{"%@gmail.com"},
 		},
 	}
@@ -0,0 +1,189 @@
+package sq
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type prettyTestLogger struct {
+	outputs []string
+}
+
+func (l *prettyTestLogger) Output(calldepth int, s string) error {
+	l.outputs = append(l.outputs, s)
+	return nil
+}
+
+func TestToSQLPretty(t *testing.T) {
+	type TT struct {
+		description string
+		q           interface {
+			ToSQL() (string, []interface{})
+			ToSQLPretty(string) (string, []interface{})
+		}
+		wantQuery string
+	}
+	u, ur, s := USERS().As("u"), USER_ROLES().As("ur"), SESSIONS().As("s")
+	tests := []TT{
+		{
+			"select",
+			func() SelectQuery {
+				cte := From(ur).Where(ur.ROLE.EqString("admin")).Select(ur.USER_ID).CTE("admins")
+				subq := From(s).GroupBy(s.USER_ID).Select(s.USER_ID, Count().As("sessions")).Subquery("subq")
+				return From(u).
+					Join(cte, cte.NumberField("user_id").Eq(u.USER_ID)).
+					LeftJoin(subq, subq.NumberField("user_id").Eq(u.USER_ID), subq.NumberField("sessions").GtInt(1)).
+					Where(
+						u.EMAIL.LikeString("%@gmail.com"),
+						Or(u.DISPLAYNAME.IsNull(), u.DISPLAYNAME.EqString("")),
+						Exists(From(s).Where(s.USER_ID.Eq(u.USER_ID)).SelectOne()),
+					).
+					OrderBy(u.USER_ID).
+					Limit(10).
+					Select(u.USER_ID, subq.NumberField("sessions"))
+			}(),
+			"WITH admins AS (" +
+				"\n    SELECT ur.user_id" +
+				"\n    FROM public.user_roles AS ur" +
+				"\n    WHERE ur.role = $1" +
+				"\n)" +
+				"\nSELECT u.user_id, subq.sessions" +
+				"\nFROM public.users AS u" +
+				"\nJOIN admins" +
+				"\n    ON admins.user_id = u.user_id" +
+				"\nLEFT JOIN (" +
+				"\n    SELECT s.user_id, COUNT(*) AS sessions" +
+				"\n    FROM public.sessions AS s" +
+				"\n    GROUP BY s.user_id" +
+				"\n) AS subq" +
+				"\n    ON subq.user_id = u.user_id" +
+				"\n        AND subq.sessions > $2" +
+				"\nWHERE u.email LIKE $3" +
+				"\n    AND (" +
+				"\n        u.displayname IS NULL" +
+				"\n        OR u.displayname = $4" +
+				"\n    )" +
+				"\n    AND EXISTS(" +
+				"\n        SELECT 1" +
+				"\n        FROM public.sessions AS s" +
+				"\n        WHERE s.user_id = u.user_id" +
+				"\n    )" +
+				"\nORDER BY u.user_id" +
+				"\nLIMIT $5",
+		},
+		{
+			"union",
+			Union(
+				From(u).Select(u.USER_ID),
+				Intersect(From(ur).Select(ur.USER_ID), From(s).Select(s.USER_ID)),
+			).OrderBy(u.USER_ID),
+			"SELECT u.user_id" +
+				"\nFROM public.users AS u" +
+				"\nUNION" +
+				"\n(" +
+				"\n    SELECT ur.user_id" +
+				"\n    FROM public.user_roles AS ur" +
+				"\n    INTERSECT" +
+				"\n    SELECT s.user_id" +
+				"\n    FROM public.sessions AS s" +
+				"\n)" +
+				"\nORDER BY user_id",
+		},
+		{
+			"insert select",
+			InsertInto(u).
+				Columns(u.USER_ID).
+				Select(From(ur).Where(ur.ROLE.EqString("a"), ur.COHORT.IsNull()).Select(ur.USER_ID)).
+				OnConflict(u.USER_ID).
+				DoNothing(),
+			"INSERT INTO public.users AS u (user_id)" +
+				"\nSELECT ur.user_id" +
+				"\nFROM public.user_roles AS ur" +
+				"\nWHERE ur.role = $1" +
+				"\n    AND ur.cohort IS NULL" +
+				"\nON CONFLICT (user_id)" +
+				"\nDO NOTHING",
+		},
+		{
+			"update",
+			Update(u).
+				Set(u.EMAIL.SetString("x")).
+				From(ur).
+				Where(ur.USER_ID.Eq(u.USER_ID)).
+				Returning(u.USER_ID),
+			"UPDATE public.users AS u" +
+				"\nSET email = $1" +
+				"\nFROM public.user_roles AS ur" +
+				"\nWHERE ur.user_id = u.user_id" +
+				"\nRETURNING u.user_id",
+		},
+		{
+			"delete",
+			DeleteFrom(u).
+				Where(u.USER_ID.In(From(s).Where(s.HASH.IsNull()).Select(s.USER_ID))),
+			"DELETE FROM public.users AS u" +
+				"\nWHERE u.user_id IN (" +
+				"\n    SELECT s.user_id" +
+				"\n    FROM public.sessions AS s" +
+				"\n    WHERE s.hash IS NULL" +
+				"\n)",
+		},
+		{
+			"any of",
+			DeleteFrom(u).
+				Where(Eq(u.USER_ID, AnyOf(From(s).Where(s.HASH.IsNull()).Select(s.USER_ID)))),
+			"DELETE FROM public.users AS u" +
+				"\nWHERE u.user_id = ANY (" +
+				"\n    SELECT s.user_id" +
+				"\n    FROM public.sessions AS s" +
+				"\n    WHERE s.hash IS NULL" +
+				"\n)",
+		},
+	}
+	// collapse undoes the pretty printing, so that it can be compared with
+	// ToSQL.
+	newlines := regexp.MustCompile(`\s*\n\s*`)
+	collapse := func(query string) string {
+		query = newlines.ReplaceAllString(query, " ")
+		return strings.NewReplacer("( ", "(", " )", ")").Replace(query)
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQLPretty("    ")
+			is.Equal(tt.wantQuery, gotQuery)
+			wantQuery, wantArgs := tt.q.ToSQL()
+			is.Equal(wantQuery, collapse(gotQuery))
+			is.Equal(wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestToSQLPretty_Lpretty(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	logger := &prettyTestLogger{}
+	q := From(u).Where(u.USER_ID.EqInt(1), u.EMAIL.IsNotNull()).Select(u.USER_ID)
+	q.Log, q.LogFlag = logger, Lpretty
+	gotQuery, _ := q.ToSQL()
+	// only the logged query is pretty printed
+	is.Equal("SELECT u.user_id FROM public.users AS u WHERE u.user_id = $1 AND u.email IS NOT NULL", gotQuery)
+	wantQuery := "SELECT u.user_id" +
+		"\nFROM public.users AS u" +
+		"\nWHERE u.user_id = $1" +
+		"\n    AND u.email IS NOT NULL"
+	is.Equal([]string{wantQuery + " [1]"}, logger.outputs)
+
+	logger.outputs = nil
+	q.LogFlag = Lpretty | Linterpolate
+	_, _ = q.ToSQL()
+	is.Equal([]string{"SELECT u.user_id" +
+		"\nFROM public.users AS u" +
+		"\nWHERE u.user_id = 1" +
+		"\n    AND u.email IS NOT NULL"}, logger.outputs)
+}
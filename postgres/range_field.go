@@ -0,0 +1,640 @@
+package sq
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RangeField either represents a range column or a range expression. It is
+// also used for multirange columns, since they support the same operators.
+type RangeField struct {
+	// RangeField will be one of the following:
+
+	// 1) Range expression
+	// Examples of range expressions:
+	// | query                        | args                 |
+	// |------------------------------|----------------------|
+	// | int4range(?, ?, ?)           | 1, 10, []            |
+	// | tstzrange(?, NULL, ?)        | 2020-06-24 00:00, [) |
+	// | bookings.during * ?::tsrange | [2020-01-01,)        |
+	format *string
+	values []interface{}
+
+	// 2) Range column
+	// Examples of range columns:
+	// | query           | args |
+	// |-----------------|------|
+	// | bookings.during |      |
+	// | during          |      |
+	alias string
+	table Table
+	name  string
+}
+
+// AppendSQLExclude marshals the RangeField into an SQL query and args as
+// described in the RangeField internal struct comments.
+func (f RangeField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	switch {
+	case f.format != nil:
+		// 1) Range expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
+	default:
+		// 2) Range column
+		tableQualifier := f.table.GetAlias()
+		if tableQualifier == "" {
+			tableQualifier = f.table.GetName()
+		}
+		for _, excludedTableQualifier := range excludedTableQualifiers {
+			if tableQualifier == excludedTableQualifier {
+				tableQualifier = ""
+				break
+			}
+		}
+		if tableQualifier != "" {
+			if strings.ContainsAny(tableQualifier, " \t") {
+				buf.WriteString(`"`)
+				buf.WriteString(tableQualifier)
+				buf.WriteString(`".`)
+			} else {
+				buf.WriteString(tableQualifier)
+				buf.WriteString(".")
+			}
+		}
+		if strings.ContainsAny(f.name, " \t") {
+			buf.WriteString(`"`)
+			buf.WriteString(f.name)
+			buf.WriteString(`"`)
+		} else {
+			buf.WriteString(f.name)
+		}
+	}
+}
+
+// NewRangeField returns a new RangeField representing a range column.
+func NewRangeField(name string, table Table) RangeField {
+	return RangeField{
+		name:  name,
+		table: table,
+	}
+}
+
+// RangeFieldf creates a new range expression.
+func RangeFieldf(format string, values ...interface{}) RangeField {
+	return RangeField{
+		format: &format,
+		values: values,
+	}
+}
+
+// RangeBounds specifies whether the lower and upper bounds of a range are
+// inclusive or exclusive.
+type RangeBounds string
+
+// RangeBounds
+const (
+	RangeBoundsInclusive      RangeBounds = "[]"
+	RangeBoundsExclusive      RangeBounds = "()"
+	RangeBoundsLowerInclusive RangeBounds = "[)"
+	RangeBoundsUpperInclusive RangeBounds = "(]"
+)
+
+// rangeConstructor calls one of the built-in range constructor functions. A
+// nil bound is rendered as NULL, which makes that side of the range unbounded.
+func rangeConstructor(name string, lower, upper interface{}, bounds RangeBounds) RangeField {
+	format := name + "(?, ?, ?)"
+	if lower == nil && upper == nil {
+		format = name + "(NULL, NULL, ?)"
+	} else if lower == nil {
+		format = name + "(NULL, ?, ?)"
+	} else if upper == nil {
+		format = name + "(?, NULL, ?)"
+	}
+	var values []interface{}
+	if lower != nil {
+		values = append(values, lower)
+	}
+	if upper != nil {
+		values = append(values, upper)
+	}
+	values = append(values, string(bounds))
+	return RangeField{
+		format: &format,
+		values: values,
+	}
+}
+
+// Int4Range represents the int4range() range constructor.
+func Int4Range(lower, upper interface{}, bounds RangeBounds) RangeField {
+	return rangeConstructor("int4range", lower, upper, bounds)
+}
+
+// Int8Range represents the int8range() range constructor.
+func Int8Range(lower, upper interface{}, bounds RangeBounds) RangeField {
+	return rangeConstructor("int8range", lower, upper, bounds)
+}
+
+// NumRange represents the numrange() range constructor.
+func NumRange(lower, upper interface{}, bounds RangeBounds) RangeField {
+	return rangeConstructor("numrange", lower, upper, bounds)
+}
+
+// TSRange represents the tsrange() range constructor.
+func TSRange(lower, upper interface{}, bounds RangeBounds) RangeField {
+	return rangeConstructor("tsrange", lower, upper, bounds)
+}
+
+// TSTZRange represents the tstzrange() range constructor.
+func TSTZRange(lower, upper interface{}, bounds RangeBounds) RangeField {
+	return rangeConstructor("tstzrange", lower, upper, bounds)
+}
+
+// DateRange represents the daterange() range constructor.
+func DateRange(lower, upper interface{}, bounds RangeBounds) RangeField {
+	return rangeConstructor("daterange", lower, upper, bounds)
+}
+
+// Set returns a FieldAssignment associating the RangeField to the value i.e.
+// 'field = value'.
+func (f RangeField) Set(value interface{}) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: value,
+	}
+}
+
+// As returns a new RangeField with the new field Alias i.e. 'field AS Alias'.
+func (f RangeField) As(alias string) RangeField {
+	f.alias = alias
+	return f
+}
+
+// IsNull returns an 'X IS NULL' Predicate.
+func (f RangeField) IsNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// IsNotNull returns an 'X IS NOT NULL' Predicate.
+func (f RangeField) IsNotNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NOT NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// Eq returns an 'X = Y' Predicate. It only accepts RangeField.
+func (f RangeField) Eq(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? = ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// Ne returns an 'X <> Y' Predicate. It only accepts RangeField.
+func (f RangeField) Ne(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? <> ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// Contains returns an 'X @> Y' Predicate. The value may be another range or a
+// single element of the range.
+func (f RangeField) Contains(value interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? @> ?",
+		Values: []interface{}{f, value},
+	}
+}
+
+// ContainedBy returns an 'X <@ Y' Predicate. It only accepts RangeField.
+func (f RangeField) ContainedBy(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? <@ ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// Overlaps returns an 'X && Y' Predicate. It only accepts RangeField. This is
+// the operator typically used in an EXCLUDE USING gist (... WITH &&)
+// constraint, so querying with it can use the same index.
+func (f RangeField) Overlaps(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? && ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// Adjacent returns an 'X -|- Y' Predicate. It only accepts RangeField.
+func (f RangeField) Adjacent(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? -|- ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// StrictlyLeftOf returns an 'X << Y' Predicate. It only accepts RangeField.
+func (f RangeField) StrictlyLeftOf(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? << ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// StrictlyRightOf returns an 'X >> Y' Predicate. It only accepts RangeField.
+func (f RangeField) StrictlyRightOf(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? >> ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// DoesNotExtendRightOf returns an 'X &< Y' Predicate. It only accepts
+// RangeField.
+func (f RangeField) DoesNotExtendRightOf(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? &< ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// DoesNotExtendLeftOf returns an 'X &> Y' Predicate. It only accepts
+// RangeField.
+func (f RangeField) DoesNotExtendLeftOf(field RangeField) Predicate {
+	return CustomPredicate{
+		Format: "? &> ?",
+		Values: []interface{}{f, field},
+	}
+}
+
+// Lower represents the lower() function, which returns the lower bound of the
+// range (NULL if the range is empty or unbounded below).
+func (f RangeField) Lower() CustomField {
+	return CustomField{
+		Format: "lower(?)",
+		Values: []interface{}{f},
+	}
+}
+
+// Upper represents the upper() function, which returns the upper bound of the
+// range (NULL if the range is empty or unbounded above).
+func (f RangeField) Upper() CustomField {
+	return CustomField{
+		Format: "upper(?)",
+		Values: []interface{}{f},
+	}
+}
+
+// IsEmpty represents the isempty() function.
+func (f RangeField) IsEmpty() Predicate {
+	return CustomPredicate{
+		Format: "isempty(?)",
+		Values: []interface{}{f},
+	}
+}
+
+// Intersection returns a new RangeField representing the intersection of both
+// ranges i.e. 'X * Y'.
+func (f RangeField) Intersection(field RangeField) RangeField {
+	format := "? * ?"
+	return RangeField{
+		format: &format,
+		values: []interface{}{f, field},
+	}
+}
+
+// String implements the fmt.Stringer interface. It returns the string
+// representation of a RangeField.
+func (f RangeField) String() string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	f.AppendSQLExclude(buf, &args, nil, nil)
+	return questionInterpolate(buf.String(), args...)
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// RangeField.
+func (f RangeField) GetAlias() string {
+	return f.alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// RangeField.
+func (f RangeField) GetName() string {
+	return f.name
+}
+
+// Range is the Go representation of a Postgres range value. A nil Lower or
+// Upper means the range is unbounded on that side.
+//
+// Range implements sql.Scanner and driver.Valuer. When scanned directly the
+// bounds are left as strings, use Row.Int64Range, Row.Float64Range or
+// Row.TimeRange to obtain typed bounds.
+type Range struct {
+	Lower          interface{}
+	Upper          interface{}
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+	Valid          bool // Valid is true if the range is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (rng *Range) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*rng = Range{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("cannot scan %T into a Range", value)
+	}
+	parsed, err := parseRange(s)
+	if err != nil {
+		return err
+	}
+	*rng = parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns the range in its
+// Postgres text representation e.g. '[1,10)'.
+func (rng Range) Value() (driver.Value, error) {
+	if !rng.Valid {
+		return nil, nil
+	}
+	if rng.Empty {
+		return "empty", nil
+	}
+	buf := &strings.Builder{}
+	if rng.LowerInclusive && rng.Lower != nil {
+		buf.WriteString("[")
+	} else {
+		buf.WriteString("(")
+	}
+	buf.WriteString(formatRangeBound(rng.Lower))
+	buf.WriteString(",")
+	buf.WriteString(formatRangeBound(rng.Upper))
+	if rng.UpperInclusive && rng.Upper != nil {
+		buf.WriteString("]")
+	} else {
+		buf.WriteString(")")
+	}
+	return buf.String(), nil
+}
+
+// Multirange is the Go representation of a Postgres multirange value.
+//
+// Multirange implements sql.Scanner and driver.Valuer. When scanned directly
+// the bounds are left as strings, use Row.Int64Multirange,
+// Row.Float64Multirange or Row.TimeMultirange to obtain typed bounds.
+type Multirange struct {
+	Ranges []Range
+	Valid  bool // Valid is true if the multirange is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (mr *Multirange) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*mr = Multirange{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("cannot scan %T into a Multirange", value)
+	}
+	parsed, err := parseMultirange(s)
+	if err != nil {
+		return err
+	}
+	*mr = parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns the multirange in
+// its Postgres text representation e.g. '{[1,3),[5,7)}'.
+func (mr Multirange) Value() (driver.Value, error) {
+	if !mr.Valid {
+		return nil, nil
+	}
+	buf := &strings.Builder{}
+	buf.WriteString("{")
+	for i, rng := range mr.Ranges {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		rng.Valid = true
+		value, err := rng.Value()
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(value.(string))
+	}
+	buf.WriteString("}")
+	return buf.String(), nil
+}
+
+// formatRangeBound formats a bound for the range text representation. A nil
+// bound is rendered as an empty string i.e. unbounded.
+func formatRangeBound(bound interface{}) string {
+	var s string
+	switch v := bound.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		s = v.Format(time.RFC3339Nano)
+	default:
+		s = fmt.Sprint(v)
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// parseRange parses the Postgres text representation of a range. The bounds
+// are returned as strings.
+func parseRange(s string) (Range, error) {
+	rng := Range{Valid: true}
+	if s == "empty" {
+		rng.Empty = true
+		return rng, nil
+	}
+	if len(s) < 3 {
+		return Range{}, fmt.Errorf("%q is not a valid range", s)
+	}
+	switch s[0] {
+	case '[':
+		rng.LowerInclusive = true
+	case '(':
+	default:
+		return Range{}, fmt.Errorf("%q is not a valid range", s)
+	}
+	switch s[len(s)-1] {
+	case ']':
+		rng.UpperInclusive = true
+	case ')':
+	default:
+		return Range{}, fmt.Errorf("%q is not a valid range", s)
+	}
+	var bounds []*string
+	var current strings.Builder
+	var quoted, touched bool
+	body := s[1 : len(s)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body):
+			i++
+			current.WriteByte(body[i])
+			touched = true
+		case c == '"':
+			quoted = !quoted
+			touched = true
+		case c == ',' && !quoted:
+			bounds = append(bounds, rangeBound(current.String(), touched))
+			current.Reset()
+			touched = false
+		default:
+			current.WriteByte(c)
+			touched = true
+		}
+	}
+	bounds = append(bounds, rangeBound(current.String(), touched))
+	if len(bounds) != 2 || quoted {
+		return Range{}, fmt.Errorf("%q is not a valid range", s)
+	}
+	if bounds[0] != nil {
+		rng.Lower = *bounds[0]
+	} else {
+		rng.LowerInclusive = false
+	}
+	if bounds[1] != nil {
+		rng.Upper = *bounds[1]
+	} else {
+		rng.UpperInclusive = false
+	}
+	return rng, nil
+}
+
+// parseMultirange parses the Postgres text representation of a multirange.
+// The bounds are returned as strings.
+func parseMultirange(s string) (Multirange, error) {
+	mr := Multirange{Valid: true}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return Multirange{}, fmt.Errorf("%q is not a valid multirange", s)
+	}
+	body := s[1 : len(s)-1]
+	var quoted bool
+	start := 0
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ']' || c == ')':
+			rng, err := parseRange(strings.TrimSpace(body[start : i+1]))
+			if err != nil {
+				return Multirange{}, err
+			}
+			mr.Ranges = append(mr.Ranges, rng)
+			start = i + 1
+			for start < len(body) && body[start] == ' ' {
+				start++
+			}
+			if start < len(body) {
+				if body[start] != ',' {
+					return Multirange{}, fmt.Errorf("%q is not a valid multirange", s)
+				}
+				start++
+			}
+			i = start - 1
+		}
+	}
+	if quoted || strings.TrimSpace(body[start:]) != "" {
+		return Multirange{}, fmt.Errorf("%q is not a valid multirange", s)
+	}
+	return mr, nil
+}
+
+// rangeBound returns nil if the bound is missing i.e. unbounded.
+func rangeBound(s string, touched bool) *string {
+	if !touched {
+		return nil
+	}
+	return &s
+}
+
+// convertBounds converts the string bounds of a scanned Range using convert.
+// If convert returns a nil bound, that side of the range becomes unbounded.
+func (rng Range) convertBounds(convert func(string) (interface{}, error)) (Range, error) {
+	var err error
+	if s, ok := rng.Lower.(string); ok {
+		rng.Lower, err = convert(s)
+		if err != nil {
+			return rng, err
+		}
+		if rng.Lower == nil {
+			rng.LowerInclusive = false
+		}
+	}
+	if s, ok := rng.Upper.(string); ok {
+		rng.Upper, err = convert(s)
+		if err != nil {
+			return rng, err
+		}
+		if rng.Upper == nil {
+			rng.UpperInclusive = false
+		}
+	}
+	return rng, nil
+}
+
+// rangeTimeLayouts are the layouts that a tsrange, tstzrange or daterange
+// bound may take.
+var rangeTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	time.RFC3339Nano,
+}
+
+// parseRangeInt64 parses an int4range or int8range bound.
+func parseRangeInt64(s string) (interface{}, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// parseRangeFloat64 parses a numrange bound.
+func parseRangeFloat64(s string) (interface{}, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseRangeTime parses a tsrange, tstzrange or daterange bound. An infinity
+// or -infinity bound is returned as nil i.e. unbounded, as time.Time has no
+// way of representing it.
+func parseRangeTime(s string) (interface{}, error) {
+	if s == "infinity" || s == "-infinity" {
+		return nil, nil
+	}
+	for _, layout := range rangeTimeLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not a valid time", s)
+}
@@ -0,0 +1,348 @@
+package sq
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRangeField_AppendSQLExclude(t *testing.T) {
+	type TT struct {
+		description string
+		f           RangeField
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			desc := "table qualified"
+			f := NewRangeField("during", &TableInfo{Schema: "public", Name: "bookings"})
+			wantQuery := "bookings.during"
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "excludedTableQualifiers (alias)"
+			f := NewRangeField("during", &TableInfo{Schema: "public", Name: "bookings", Alias: "b"})
+			exclude := []string{"b"}
+			wantQuery := "during"
+			return TT{desc, f, exclude, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "quoted whitespace"
+			f := NewRangeField("booked during", &TableInfo{Schema: "public", Name: "room bookings"})
+			wantQuery := `"room bookings"."booked during"`
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "Int4Range"
+			f := Int4Range(1, 10, RangeBoundsInclusive)
+			wantQuery := "int4range(?, ?, ?)"
+			wantArgs := []interface{}{1, 10, "[]"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "Int8Range unbounded below"
+			f := Int8Range(nil, 10, RangeBoundsUpperInclusive)
+			wantQuery := "int8range(NULL, ?, ?)"
+			wantArgs := []interface{}{10, "(]"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "TSTZRange unbounded above"
+			start := time.Date(2020, 6, 24, 0, 0, 0, 0, time.UTC)
+			f := TSTZRange(start, nil, RangeBoundsLowerInclusive)
+			wantQuery := "tstzrange(?, NULL, ?)"
+			wantArgs := []interface{}{start, "[)"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "DateRange unbounded"
+			f := DateRange(nil, nil, RangeBoundsExclusive)
+			wantQuery := "daterange(NULL, NULL, ?)"
+			wantArgs := []interface{}{"()"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "NumRange Intersection"
+			f := NumRange(1.5, 2.5, RangeBoundsLowerInclusive).Intersection(TSRange("a", "b", RangeBoundsLowerInclusive))
+			wantQuery := "numrange(?, ?, ?) * tsrange(?, ?, ?)"
+			wantArgs := []interface{}{1.5, 2.5, "[)", "a", "b", "[)"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			var _ Field = tt.f
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestRangeField_Predicates(t *testing.T) {
+	type TT struct {
+		description string
+		p           Predicate
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	f := NewRangeField("during", &TableInfo{Schema: "public", Name: "bookings", Alias: "b"})
+	g := Int4Range(1, 5, RangeBoundsLowerInclusive)
+	tests := []TT{
+		{"IsNull", f.IsNull(), nil, "b.during IS NULL", nil},
+		{"IsNotNull", f.IsNotNull(), nil, "b.during IS NOT NULL", nil},
+		{"Eq", f.Eq(g), nil, "b.during = int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"Ne", f.Ne(g), nil, "b.during <> int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"Contains element", f.Contains(3), nil, "b.during @> ?", []interface{}{3}},
+		{"Contains range", f.Contains(g), nil, "b.during @> int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"ContainedBy", f.ContainedBy(g), nil, "b.during <@ int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"Overlaps", f.Overlaps(g), []string{"b"}, "during && int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"Adjacent", f.Adjacent(g), nil, "b.during -|- int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"StrictlyLeftOf", f.StrictlyLeftOf(g), nil, "b.during << int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"StrictlyRightOf", f.StrictlyRightOf(g), nil, "b.during >> int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"DoesNotExtendRightOf", f.DoesNotExtendRightOf(g), nil, "b.during &< int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"DoesNotExtendLeftOf", f.DoesNotExtendLeftOf(g), nil, "b.during &> int4range(?, ?, ?)", []interface{}{1, 5, "[)"}},
+		{"IsEmpty", f.IsEmpty().Not(), nil, "NOT isempty(b.during)", nil},
+		{"Lower", f.Lower().Gt(2), nil, "lower(b.during) > ?", []interface{}{2}},
+		{"Upper", f.Upper().IsNull(), nil, "upper(b.during) IS NULL", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.p.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestRange_Scan(t *testing.T) {
+	type TT struct {
+		description string
+		value       interface{}
+		wantRange   Range
+		wantErr     bool
+	}
+	tests := []TT{
+		{"NULL", nil, Range{}, false},
+		{"empty", []byte("empty"), Range{Empty: true, Valid: true}, false},
+		{"int4range", []byte("[1,10)"), Range{Lower: "1", Upper: "10", LowerInclusive: true, Valid: true}, false},
+		{"unbounded below", "(,10]", Range{Upper: "10", UpperInclusive: true, Valid: true}, false},
+		{"unbounded", "(,)", Range{Valid: true}, false},
+		{
+			"quoted bounds",
+			[]byte(`["2020-06-24 00:00:00+00","2020-06-25 00:00:00+00")`),
+			Range{Lower: "2020-06-24 00:00:00+00", Upper: "2020-06-25 00:00:00+00", LowerInclusive: true, Valid: true},
+			false,
+		},
+		{"escaped quote", []byte(`["a\"b",c)`), Range{Lower: `a"b`, Upper: "c", LowerInclusive: true, Valid: true}, false},
+		{"invalid brackets", "{1,2}", Range{}, true},
+		{"too many bounds", "[1,2,3)", Range{}, true},
+		{"multirange", "{[1,3),[5,7)}", Range{}, true},
+		{"unsupported type", 5, Range{}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			var rng Range
+			err := rng.Scan(tt.value)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(tt.wantRange, rng)
+		})
+	}
+}
+
+func TestRange_Value(t *testing.T) {
+	type TT struct {
+		description string
+		rng         Range
+		wantValue   interface{}
+	}
+	tests := []TT{
+		{"NULL", Range{}, nil},
+		{"empty", Range{Empty: true, Valid: true}, "empty"},
+		{"inclusive", Range{Lower: 1, Upper: 10, LowerInclusive: true, UpperInclusive: true, Valid: true}, `["1","10"]`},
+		{"unbounded above", Range{Lower: 1, LowerInclusive: true, Valid: true}, `["1",)`},
+		{
+			"time",
+			Range{Lower: time.Date(2020, 6, 24, 0, 0, 0, 0, time.UTC), LowerInclusive: true, Valid: true},
+			`["2020-06-24T00:00:00Z",)`,
+		},
+		{"quoted", Range{Lower: `a"b`, Upper: "c", Valid: true}, `("a\"b","c")`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			value, err := tt.rng.Value()
+			is.NoErr(err)
+			is.Equal(tt.wantValue, value)
+			if value != nil {
+				var rng Range
+				is.NoErr(rng.Scan(value))
+				is.Equal(tt.rng.Empty, rng.Empty)
+				is.Equal(tt.rng.LowerInclusive, rng.LowerInclusive)
+				is.Equal(tt.rng.UpperInclusive, rng.UpperInclusive)
+			}
+		})
+	}
+}
+
+func TestRow_Range(t *testing.T) {
+	is := is.New(t)
+	f := NewRangeField("during", &TableInfo{Schema: "public", Name: "bookings"})
+	mapper := func(row *Row) (Range, Range, Range, Range, Range) {
+		return row.Int64Range(f), row.Float64Range(f), row.TimeRange(f), row.TimeRange(f), row.TimeRange(f)
+	}
+	r := &Row{}
+	mapper(r)
+	is.Equal(5, len(r.fields))
+	// Populate the destinations as if they had been scanned.
+	r.jsonValues = make([]json.RawMessage, len(r.fields))
+	*r.dest[0].(*sql.NullString) = sql.NullString{String: "[1,10)", Valid: true}
+	*r.dest[1].(*sql.NullString) = sql.NullString{String: "(1.5,2.5]", Valid: true}
+	*r.dest[2].(*sql.NullString) = sql.NullString{String: `["2020-06-24 08:00:00+08",)`, Valid: true}
+	*r.dest[3].(*sql.NullString) = sql.NullString{}
+	*r.dest[4].(*sql.NullString) = sql.NullString{String: `[-infinity,"2020-06-24 00:00:00"]`, Valid: true}
+	ints, floats, times, null, infinite := mapper(r)
+	is.Equal(Range{Lower: int64(1), Upper: int64(10), LowerInclusive: true, Valid: true}, ints)
+	is.Equal(Range{Lower: 1.5, Upper: 2.5, UpperInclusive: true, Valid: true}, floats)
+	is.True(times.Lower.(time.Time).Equal(time.Date(2020, 6, 24, 0, 0, 0, 0, time.UTC)))
+	is.Equal(nil, times.Upper)
+	is.Equal(Range{}, null)
+	is.Equal(Range{Upper: time.Date(2020, 6, 24, 0, 0, 0, 0, time.UTC), UpperInclusive: true, Valid: true}, infinite)
+}
+
+func TestMultirange_Scan(t *testing.T) {
+	type TT struct {
+		description    string
+		value          interface{}
+		wantMultirange Multirange
+		wantErr        bool
+	}
+	tests := []TT{
+		{"NULL", nil, Multirange{}, false},
+		{"empty", []byte("{}"), Multirange{Valid: true}, false},
+		{
+			"int4multirange",
+			[]byte("{[1,3),[5,7)}"),
+			Multirange{Ranges: []Range{
+				{Lower: "1", Upper: "3", LowerInclusive: true, Valid: true},
+				{Lower: "5", Upper: "7", LowerInclusive: true, Valid: true},
+			}, Valid: true},
+			false,
+		},
+		{
+			"quoted bounds",
+			`{["a)b","c"], (,"d,e")}`,
+			Multirange{Ranges: []Range{
+				{Lower: "a)b", Upper: "c", LowerInclusive: true, UpperInclusive: true, Valid: true},
+				{Upper: "d,e", Valid: true},
+			}, Valid: true},
+			false,
+		},
+		{"range", "[1,3)", Multirange{}, true},
+		{"missing comma", "{[1,3)[5,7)}", Multirange{}, true},
+		{"trailing garbage", "{[1,3),5}", Multirange{}, true},
+		{"unsupported type", 5, Multirange{}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			var mr Multirange
+			err := mr.Scan(tt.value)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(tt.wantMultirange, mr)
+		})
+	}
+}
+
+func TestMultirange_Value(t *testing.T) {
+	type TT struct {
+		description string
+		mr          Multirange
+		wantValue   interface{}
+	}
+	tests := []TT{
+		{"NULL", Multirange{}, nil},
+		{"empty", Multirange{Valid: true}, "{}"},
+		{
+			"ranges",
+			Multirange{Ranges: []Range{
+				{Lower: 1, Upper: 3, LowerInclusive: true},
+				{Lower: 5, UpperInclusive: true},
+			}, Valid: true},
+			`{["1","3"),("5",)}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			value, err := tt.mr.Value()
+			is.NoErr(err)
+			is.Equal(tt.wantValue, value)
+			if value != nil {
+				var mr Multirange
+				is.NoErr(mr.Scan(value))
+				is.Equal(len(tt.mr.Ranges), len(mr.Ranges))
+			}
+		})
+	}
+}
+
+func TestRow_Multirange(t *testing.T) {
+	is := is.New(t)
+	f := NewRangeField("during", &TableInfo{Schema: "public", Name: "bookings"})
+	mapper := func(row *Row) (Multirange, Multirange, Multirange, Multirange) {
+		return row.Int64Multirange(f), row.Float64Multirange(f), row.TimeMultirange(f), row.TimeMultirange(f)
+	}
+	r := &Row{}
+	mapper(r)
+	is.Equal(4, len(r.fields))
+	// Populate the destinations as if they had been scanned.
+	r.jsonValues = make([]json.RawMessage, len(r.fields))
+	*r.dest[0].(*sql.NullString) = sql.NullString{String: "{[1,3),[5,7)}", Valid: true}
+	*r.dest[1].(*sql.NullString) = sql.NullString{String: "{(1.5,2.5]}", Valid: true}
+	*r.dest[2].(*sql.NullString) = sql.NullString{String: `{["2020-06-24",)}`, Valid: true}
+	*r.dest[3].(*sql.NullString) = sql.NullString{}
+	ints, floats, times, null := mapper(r)
+	is.Equal(Multirange{Ranges: []Range{
+		{Lower: int64(1), Upper: int64(3), LowerInclusive: true, Valid: true},
+		{Lower: int64(5), Upper: int64(7), LowerInclusive: true, Valid: true},
+	}, Valid: true}, ints)
+	is.Equal(Multirange{Ranges: []Range{{Lower: 1.5, Upper: 2.5, UpperInclusive: true, Valid: true}}, Valid: true}, floats)
+	is.Equal(1, len(times.Ranges))
+	is.True(times.Ranges[0].Lower.(time.Time).Equal(time.Date(2020, 6, 24, 0, 0, 0, 0, time.UTC)))
+	is.Equal(Multirange{}, null)
+}
@@ -2,6 +2,7 @@ package sq
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"runtime"
 	"strconv"
@@ -31,13 +32,16 @@ type Row struct {
 	fields  []Field
 	dest    []interface{}
 	tmpdest []interface{}
+	// jsonValues holds the values of the current element when the Row is
+	// being populated from a JSONAggregate instead of from *sql.Rows.
+	jsonValues []json.RawMessage
 }
 
 /* custom */
 
 // ScanInto scans the field into a dest, where dest is a pointer.
 func (r *Row) ScanInto(dest interface{}, field Field) {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		switch dest.(type) {
 		case *bool, *sql.NullBool:
@@ -98,6 +102,14 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 		nulltime := r.dest[r.index].(*sql.NullTime)
 		*ptr = *nulltime
 	default:
+		if r.jsonValues != nil {
+			err := scanJSONValue(dest, r.fields[r.index], r.jsonValues[r.index])
+			if err != nil {
+				_, sourcefile, linenbr, _ := runtime.Caller(1)
+				panic(fmt.Errorf("row.ScanInto failed on %s:%d: %w", sourcefile, linenbr, err))
+			}
+			break
+		}
 		var nothing interface{}
 		if len(r.tmpdest) != len(r.dest) {
 			r.tmpdest = make([]interface{}, len(r.dest))
@@ -120,11 +132,24 @@ func (r *Row) ScanInto(dest interface{}, field Field) {
 // Only []bool, []float64, []int64 or []string slices are supported.
 func (r *Row) ScanArray(slice interface{}, field Field) {
 	var nothing interface{}
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, pq.Array(slice))
 		return
 	}
+	if r.jsonValues != nil {
+		// JSON arrays are decoded directly into the slice, the postgres array
+		// parser in pq.Array does not understand them.
+		if raw := r.jsonValues[r.index]; len(raw) > 0 && string(raw) != "null" {
+			err := json.Unmarshal(raw, slice)
+			if err != nil {
+				_, sourcefile, linenbr, _ := runtime.Caller(1)
+				panic(fmt.Errorf("row.ScanArray failed on %s:%d: %w", sourcefile, linenbr, err))
+			}
+		}
+		r.index++
+		return
+	}
 	if len(r.tmpdest) != len(r.dest) {
 		r.tmpdest = make([]interface{}, len(r.dest))
 		for i := range r.tmpdest {
@@ -141,6 +166,28 @@ func (r *Row) ScanArray(slice interface{}, field Field) {
 	r.index++
 }
 
+// ScanJSONAgg decodes the JSON array aggregated by the JSONAggregate. For
+// each element of the array, the JSONAggregate query's mapper function is
+// called with a Row populated from that element, followed by its accumulator
+// function (if any).
+func (r *Row) ScanJSONAgg(agg JSONAggregate) {
+	if r.rows == nil && r.jsonValues == nil {
+		r.fields = append(r.fields, agg)
+		r.dest = append(r.dest, &sql.NullString{})
+		return
+	}
+	nullstring := r.dest[r.index].(*sql.NullString)
+	r.index++
+	if !nullstring.Valid {
+		return
+	}
+	err := agg.scan([]byte(nullstring.String))
+	if err != nil {
+		_, sourcefile, linenbr, _ := runtime.Caller(1)
+		panic(fmt.Errorf("row.ScanJSONAgg failed on %s:%d: %w", sourcefile, linenbr, err))
+	}
+}
+
 /* bool */
 
 // Bool returns the bool value of the Predicate. BooleanFields are considered
@@ -157,7 +204,7 @@ func (r *Row) BoolValid(predicate Predicate) bool {
 
 // NullBool returns the sql.NullBool value of the Predicate.
 func (r *Row) NullBool(predicate Predicate) sql.NullBool {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		buf := &strings.Builder{}
 		var args []interface{}
 		predicate.AppendSQLExclude(buf, &args, nil, nil)
@@ -203,7 +250,7 @@ func rowFloat64Valid(r *Row, field Field) bool {
 
 // rowNullFloat64 returns the sql.NullFloat64 value of the Field.
 func rowNullFloat64(r *Row, field Field) sql.NullFloat64 {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, &sql.NullFloat64{})
 		return sql.NullFloat64{}
@@ -264,7 +311,7 @@ func rowInt64Valid(r *Row, field NumberField) bool {
 
 // rowNullInt64 returns the sql.NullInt64 value of the Field.
 func rowNullInt64(r *Row, field Field) sql.NullInt64 {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, &sql.NullInt64{})
 		return sql.NullInt64{}
@@ -304,7 +351,7 @@ func rowStringValid(r *Row, field Field) bool {
 
 // rowNullString returns the sql.NullString value of the Field.
 func rowNullString(r *Row, field Field) sql.NullString {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, &sql.NullString{})
 		return sql.NullString{}
@@ -343,7 +390,7 @@ func rowTimeValid(r *Row, field Field) bool {
 
 // rowNullTime returns the sql.NullTime value of the Field.
 func rowNullTime(r *Row, field Field) sql.NullTime {
-	if r.rows == nil {
+	if r.rows == nil && r.jsonValues == nil {
 		r.fields = append(r.fields, field)
 		r.dest = append(r.dest, &sql.NullTime{})
 		return sql.NullTime{}
@@ -352,3 +399,128 @@ func rowNullTime(r *Row, field Field) sql.NullTime {
 	r.index++
 	return *nulltime
 }
+
+/* Range */
+
+// Int64Range returns the Range value of an int4range or int8range RangeField,
+// with int64 bounds.
+func (r *Row) Int64Range(field RangeField) Range {
+	return rowRange(r, field, parseRangeInt64)
+}
+
+// Float64Range returns the Range value of a numrange RangeField, with float64
+// bounds.
+func (r *Row) Float64Range(field RangeField) Range {
+	return rowRange(r, field, parseRangeFloat64)
+}
+
+// TimeRange returns the Range value of a tsrange, tstzrange or daterange
+// RangeField, with time.Time bounds. An infinity or -infinity bound is
+// returned as a nil bound i.e. unbounded.
+func (r *Row) TimeRange(field RangeField) Range {
+	return rowRange(r, field, parseRangeTime)
+}
+
+// rowRange returns the Range value of the Field, with its bounds converted by
+// the convert function.
+func rowRange(r *Row, field Field, convert func(string) (interface{}, error)) Range {
+	if r.rows == nil && r.jsonValues == nil {
+		r.fields = append(r.fields, field)
+		r.dest = append(r.dest, &sql.NullString{})
+		return Range{}
+	}
+	nullstring := r.dest[r.index].(*sql.NullString)
+	r.index++
+	if !nullstring.Valid {
+		return Range{}
+	}
+	rng, err := parseRange(nullstring.String)
+	if err == nil {
+		rng, err = rng.convertBounds(convert)
+	}
+	if err != nil {
+		_, sourcefile, linenbr, _ := runtime.Caller(2)
+		panic(fmt.Errorf("row.Range failed on %s:%d: %w", sourcefile, linenbr, err))
+	}
+	return rng
+}
+
+/* Multirange */
+
+// Int64Multirange returns the Multirange value of an int4multirange or
+// int8multirange RangeField, with int64 bounds.
+func (r *Row) Int64Multirange(field RangeField) Multirange {
+	return rowMultirange(r, field, parseRangeInt64)
+}
+
+// Float64Multirange returns the Multirange value of a nummultirange
+// RangeField, with float64 bounds.
+func (r *Row) Float64Multirange(field RangeField) Multirange {
+	return rowMultirange(r, field, parseRangeFloat64)
+}
+
+// TimeMultirange returns the Multirange value of a tsmultirange,
+// tstzmultirange or datemultirange RangeField, with time.Time bounds. Like
+// TimeRange, an infinity or -infinity bound is returned as a nil bound.
+func (r *Row) TimeMultirange(field RangeField) Multirange {
+	return rowMultirange(r, field, parseRangeTime)
+}
+
+// rowMultirange returns the Multirange value of the Field, with the bounds of
+// each range converted by the convert function.
+func rowMultirange(r *Row, field Field, convert func(string) (interface{}, error)) Multirange {
+	if r.rows == nil && r.jsonValues == nil {
+		r.fields = append(r.fields, field)
+		r.dest = append(r.dest, &sql.NullString{})
+		return Multirange{}
+	}
+	nullstring := r.dest[r.index].(*sql.NullString)
+	r.index++
+	if !nullstring.Valid {
+		return Multirange{}
+	}
+	mr, err := parseMultirange(nullstring.String)
+	for i := 0; err == nil && i < len(mr.Ranges); i++ {
+		mr.Ranges[i], err = mr.Ranges[i].convertBounds(convert)
+	}
+	if err != nil {
+		_, sourcefile, linenbr, _ := runtime.Caller(2)
+		panic(fmt.Errorf("row.Multirange failed on %s:%d: %w", sourcefile, linenbr, err))
+	}
+	return mr
+}
+
+/* UUID */
+
+// UUID returns the [16]byte value of the UUIDField. A NULL uuid is returned
+// as the zero value.
+func (r *Row) UUID(field UUIDField) [16]byte {
+	u, _ := rowUUID(r, field)
+	return u
+}
+
+// UUIDValid returns a bool value indicating if the UUIDField is non-NULL.
+func (r *Row) UUIDValid(field UUIDField) bool {
+	_, valid := rowUUID(r, field)
+	return valid
+}
+
+// rowUUID returns the [16]byte value of the Field and whether it is non-NULL.
+func rowUUID(r *Row, field Field) (u [16]byte, valid bool) {
+	if r.rows == nil && r.jsonValues == nil {
+		r.fields = append(r.fields, field)
+		r.dest = append(r.dest, &sql.NullString{})
+		return u, false
+	}
+	nullstring := r.dest[r.index].(*sql.NullString)
+	r.index++
+	if !nullstring.Valid {
+		return u, false
+	}
+	u, err := parseUUID(nullstring.String)
+	if err != nil {
+		_, sourcefile, linenbr, _ := runtime.Caller(2)
+		panic(fmt.Errorf("row.UUID failed on %s:%d: %w", sourcefile, linenbr, err))
+	}
+	return u, true
+}
@@ -25,6 +25,7 @@ const (
 // SelectQuery represents a SELECT query.
 type SelectQuery struct {
 	nested bool
+	format sqlFormat
 	// WITH
 	CTEs []CTE
 	// SELECT
@@ -52,6 +53,9 @@ type SelectQuery struct {
 	DB          DB
 	RowMapper   func(*Row)
 	Accumulator func()
+	// AutoValidate makes Fetch and Exec call Validate before running the
+	// query.
+	AutoValidate bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -71,7 +75,7 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
 	// WITH
 	if !q.nested {
-		appendCTEs(buf, args, q.CTEs, q.FromTable, q.JoinTables)
+		appendCTEs(buf, args, q.CTEs, q.FromTable, q.JoinTables, q.format)
 	}
 	// SELECT
 	if q.SelectType == "" {
@@ -89,11 +93,12 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// FROM
 	if q.FromTable != nil {
-		buf.WriteString(" FROM ")
+		q.format.newline(buf)
+		buf.WriteString("FROM ")
 		switch v := q.FromTable.(type) {
 		case Query:
 			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args, nil)
+			q.format.appendQuery(buf, args, v)
 			buf.WriteString(")")
 		default:
 			q.FromTable.AppendSQL(buf, args, nil)
@@ -106,39 +111,44 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
-		buf.WriteString(" ")
-		q.JoinTables.AppendSQL(buf, args, nil)
+		q.format.appendJoinTables(buf, args, q.JoinTables)
 	}
 	// WHERE
 	if len(q.WherePredicate.Predicates) > 0 {
-		buf.WriteString(" WHERE ")
+		q.format.newline(buf)
+		buf.WriteString("WHERE ")
 		q.WherePredicate.toplevel = true
-		q.WherePredicate.AppendSQLExclude(buf, args, nil, nil)
+		q.format.appendPredicate(buf, args, q.WherePredicate, nil)
 	}
 	// GROUP BY
 	if len(q.GroupByFields) > 0 {
-		buf.WriteString(" GROUP BY ")
+		q.format.newline(buf)
+		buf.WriteString("GROUP BY ")
 		q.GroupByFields.AppendSQLExclude(buf, args, nil, nil)
 	}
 	// HAVING
 	if len(q.HavingPredicate.Predicates) > 0 {
-		buf.WriteString(" HAVING ")
+		q.format.newline(buf)
+		buf.WriteString("HAVING ")
 		q.HavingPredicate.toplevel = true
-		q.HavingPredicate.AppendSQLExclude(buf, args, nil, nil)
+		q.format.appendPredicate(buf, args, q.HavingPredicate, nil)
 	}
 	// WINDOW
 	if len(q.Windows) > 0 {
-		buf.WriteString(" WINDOW ")
+		q.format.newline(buf)
+		buf.WriteString("WINDOW ")
 		q.Windows.AppendSQL(buf, args, nil)
 	}
 	// ORDER BY
 	if len(q.OrderByFields) > 0 {
-		buf.WriteString(" ORDER BY ")
+		q.format.newline(buf)
+		buf.WriteString("ORDER BY ")
 		q.OrderByFields.AppendSQLExclude(buf, args, nil, nil)
 	}
 	// LIMIT
 	if q.LimitValue != nil {
-		buf.WriteString(" LIMIT ?")
+		q.format.newline(buf)
+		buf.WriteString("LIMIT ?")
 		if *q.LimitValue < 0 {
 			*q.LimitValue = -*q.LimitValue
 		}
@@ -146,7 +156,8 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// OFFSET
 	if q.OffsetValue != nil {
-		buf.WriteString(" OFFSET ?")
+		q.format.newline(buf)
+		buf.WriteString("OFFSET ?")
 		if *q.OffsetValue < 0 {
 			*q.OffsetValue = -*q.OffsetValue
 		}
@@ -157,15 +168,21 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 		buf.Reset()
 		questionToDollarPlaceholders(buf, query)
 		if q.Log != nil {
+			logQuery, logArgs := buf.String(), *args
+			interpolate := questionInterpolate
+			if Lpretty&q.LogFlag != 0 && !q.format.pretty {
+				logQuery, logArgs = prettyLogQuery(q)
+				query, interpolate = logQuery, dollarInterpolate
+			}
 			var logOutput string
 			switch {
 			case Lstats&q.LogFlag != 0:
-				logOutput = "\n----[ Executing query ]----\n" + buf.String() + " " + fmt.Sprint(*args) +
-					"\n----[ with bind values ]----\n" + questionInterpolate(query, *args...)
+				logOutput = "\n----[ Executing query ]----\n" + logQuery + " " + fmt.Sprint(logArgs) +
+					"\n----[ with bind values ]----\n" + interpolate(query, logArgs...)
 			case Linterpolate&q.LogFlag != 0:
-				logOutput = questionInterpolate(query, *args...)
+				logOutput = interpolate(query, logArgs...)
 			default:
-				logOutput = buf.String() + " " + fmt.Sprint(*args)
+				logOutput = logQuery + " " + fmt.Sprint(logArgs)
 			}
 			switch q.Log.(type) {
 			case *log.Logger:
@@ -449,6 +466,7 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, int64(rowcount), elapsed, err)
 		if Lresults&q.LogFlag != 0 && rowcount > 5 {
 			logBuf.WriteString("\n...")
 		}
@@ -471,10 +489,16 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.SelectFields = r.fields
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return err
+		}
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
 	} else {
@@ -549,6 +573,11 @@ func (q SelectQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 		db = q.DB
 	}
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return rowsAffected, err
+		}
+	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
 	defer func() {
@@ -556,6 +585,7 @@ func (q SelectQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, rowsAffected, elapsed, err)
 		if Lstats&q.LogFlag != 0 && ErowsAffected&flag != 0 {
 			logBuf.WriteString("\n(Selected ")
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
@@ -577,6 +607,7 @@ func (q SelectQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
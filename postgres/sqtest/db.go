@@ -0,0 +1,165 @@
+// Package sqtest provides helpers for testing code that uses the postgres sq
+// package.
+package sqtest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"sync"
+
+	sq "github.com/bokwoon95/go-structured-query/postgres"
+)
+
+// DB is an in-memory fake database that implements the sq.DB interface. It
+// records every query that is run against it, and answers each query with the
+// result of the first Stub that matches it. A query that matches no Stub
+// fails with an error.
+//
+// Queries run through a database/sql driver, so the scripted rows are scanned
+// by Fetch in the same way as rows from a real database: there must be as many
+// columns as the query selects fields, and the values must be convertible to
+// the types the RowMapper scans them into.
+type DB struct {
+	*sql.DB
+	mu    sync.Mutex
+	stubs []*Stub
+	calls []Call
+}
+
+// Call is a query that was run against the DB.
+type Call struct {
+	Query string
+	Args  []interface{}
+}
+
+// Stub is the scripted result of the queries that it matches.
+type Stub struct {
+	match        func(query, fingerprint string) bool
+	columns      []string
+	rows         [][]driver.Value
+	lastInsertID int64
+	rowsAffected int64
+	err          error
+}
+
+// NewDB creates a new DB with no Stubs.
+func NewDB() *DB {
+	db := &DB{}
+	db.DB = sql.OpenDB(connector{db: db})
+	return db
+}
+
+// StubSQL adds a Stub that matches queries equal to query.
+func (db *DB) StubSQL(query string) *Stub {
+	return db.stub(func(s, _ string) bool {
+		return s == query
+	})
+}
+
+// StubRegexp adds a Stub that matches queries matching the regexp pattern. It
+// panics if the pattern does not compile.
+func (db *DB) StubRegexp(pattern string) *Stub {
+	re := regexp.MustCompile(pattern)
+	return db.stub(func(s, _ string) bool {
+		return re.MatchString(s)
+	})
+}
+
+// StubFingerprint adds a Stub that matches queries with the same shape as
+// query, i.e. queries with the same sq.Fingerprint. Only queries that are run
+// by Fetch or Exec can match, because they pass their fingerprint to the DB.
+func (db *DB) StubFingerprint(query sq.Query) *Stub {
+	fingerprint, _ := sq.Fingerprint(query)
+	return db.stub(func(_, s string) bool {
+		return s == fingerprint
+	})
+}
+
+func (db *DB) stub(match func(query, fingerprint string) bool) *Stub {
+	stub := &Stub{match: match}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.stubs = append(db.stubs, stub)
+	return stub
+}
+
+// Calls returns the queries that have been run against the DB, in the order
+// that they were run.
+func (db *DB) Calls() []Call {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	calls := make([]Call, len(db.calls))
+	copy(calls, db.calls)
+	return calls
+}
+
+// Reset removes all Stubs and Calls from the DB.
+func (db *DB) Reset() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.stubs = nil
+	db.calls = nil
+}
+
+// WantsFingerprint implements the sq.FingerprintDB interface, so that Fetch
+// and Exec pass the fingerprint of each query to the DB for StubFingerprint.
+func (db *DB) WantsFingerprint() bool {
+	return true
+}
+
+// call records the query and returns the first Stub that matches it. The
+// fingerprint is empty if the query was not run by Fetch or Exec.
+func (db *DB) call(query, fingerprint string, args []driver.NamedValue) (*Stub, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	call := Call{Query: query, Args: make([]interface{}, len(args))}
+	for i, arg := range args {
+		call.Args[i] = arg.Value
+	}
+	db.calls = append(db.calls, call)
+	for _, stub := range db.stubs {
+		if stub.match(query, fingerprint) {
+			return stub, nil
+		}
+	}
+	return nil, fmt.Errorf("sqtest: no stub matches query %q", query)
+}
+
+// Rows sets the column names and rows that the Stub returns to Fetch. Each row
+// must have one value per column, and each value must be convertible to a
+// driver.Value (ints, floats, bools, strings, []byte, time.Time, nil or a
+// driver.Valuer). Rows panics if they are not.
+func (s *Stub) Rows(columns []string, rows ...[]interface{}) *Stub {
+	s.columns = columns
+	s.rows = make([][]driver.Value, len(rows))
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			panic(fmt.Errorf("sqtest: row %d has %d values but there are %d columns", i, len(row), len(columns)))
+		}
+		s.rows[i] = make([]driver.Value, len(row))
+		for j, value := range row {
+			v, err := driver.DefaultParameterConverter.ConvertValue(value)
+			if err != nil {
+				panic(fmt.Errorf("sqtest: row %d column %s: %w", i, columns[j], err))
+			}
+			s.rows[i][j] = v
+		}
+	}
+	return s
+}
+
+// Result sets the last insert ID and the number of rows affected that the Stub
+// returns to Exec.
+func (s *Stub) Result(lastInsertID, rowsAffected int64) *Stub {
+	s.lastInsertID = lastInsertID
+	s.rowsAffected = rowsAffected
+	return s
+}
+
+// Err sets the error that the Stub returns to both Fetch and Exec.
+func (s *Stub) Err(err error) *Stub {
+	s.err = err
+	return s
+}
@@ -0,0 +1,187 @@
+package sqtest
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	sq "github.com/bokwoon95/go-structured-query/postgres"
+	"github.com/lib/pq"
+	"github.com/matryer/is"
+)
+
+type TABLE_USERS struct {
+	*sq.TableInfo
+	USER_ID sq.NumberField
+	NAME    sq.StringField
+	EMAIL   sq.StringField
+	TOKEN   sq.UUIDField
+}
+
+func USERS() TABLE_USERS {
+	tbl := TABLE_USERS{TableInfo: &sq.TableInfo{
+		Schema: "public",
+		Name:   "users",
+	}}
+	tbl.USER_ID = sq.NewNumberField("user_id", tbl.TableInfo)
+	tbl.NAME = sq.NewStringField("name", tbl.TableInfo)
+	tbl.EMAIL = sq.NewStringField("email", tbl.TableInfo)
+	tbl.TOKEN = sq.NewUUIDField("token", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_USERS) As(alias string) TABLE_USERS {
+	tbl.TableInfo.Alias = alias
+	return tbl
+}
+
+type User struct {
+	UserID int
+	Name   string
+	Email  string
+}
+
+func TestDB_Fetch(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	db := NewDB()
+	db.StubRegexp(`^SELECT u\.user_id, u\.name, u\.email FROM public\.users AS u`).
+		Rows([]string{"user_id", "name", "email"},
+			[]interface{}{1, "bob", "bob@email.com"},
+			[]interface{}{2, "alice", nil},
+		)
+	var user User
+	var users []User
+	err := sq.From(u).
+		Where(u.NAME.LikeString("%o%")).
+		Selectx(func(row *sq.Row) {
+			user.UserID = row.Int(u.USER_ID)
+			user.Name = row.String(u.NAME)
+			user.Email = row.String(u.EMAIL)
+		}, func() {
+			users = append(users, user)
+		}).
+		Fetch(db)
+	is.NoErr(err)
+	is.Equal([]User{{1, "bob", "bob@email.com"}, {2, "alice", ""}}, users)
+	is.Equal([]Call{{
+		Query: "SELECT u.user_id, u.name, u.email FROM public.users AS u WHERE u.name LIKE $1",
+		Args:  []interface{}{"%o%"},
+	}}, db.Calls())
+
+	// no rows
+	db.StubSQL("SELECT u.user_id FROM public.users AS u WHERE u.user_id = $1").
+		Rows([]string{"user_id"})
+	err = sq.From(u).
+		Where(u.USER_ID.EqInt(3)).
+		SelectRowx(func(row *sq.Row) {
+			user.UserID = row.Int(u.USER_ID)
+		}).
+		Fetch(db)
+	is.True(errors.Is(err, sql.ErrNoRows))
+}
+
+func TestDB_Exec(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	db := NewDB()
+	errDuplicate := errors.New("duplicate key")
+	db.StubFingerprint(sq.DeleteFrom(u).Where(u.USER_ID.In([]int{1}))).Result(0, 2)
+	db.StubRegexp(`^INSERT INTO`).Err(errDuplicate)
+
+	rowsAffected, err := sq.DeleteFrom(u).Where(u.USER_ID.In([]int{1, 2, 3})).Exec(db, sq.ErowsAffected)
+	is.NoErr(err)
+	is.Equal(int64(2), rowsAffected)
+
+	_, err = sq.InsertInto(u).Columns(u.USER_ID).Values(1).Exec(db, 0)
+	is.Equal(errDuplicate, err)
+
+	// unstubbed queries fail
+	_, err = sq.DeleteFrom(u).Where(u.NAME.EqString("bob")).Exec(db, 0)
+	is.True(err != nil)
+	is.Equal(3, len(db.Calls()))
+	is.Equal([]interface{}{1, 2, 3}, db.Calls()[0].Args)
+
+	db.Reset()
+	is.Equal(0, len(db.Calls()))
+	_, err = sq.DeleteFrom(u).Where(u.USER_ID.In([]int{1, 2, 3})).Exec(db, 0)
+	is.True(err != nil)
+}
+
+func TestDB_StubFingerprint(t *testing.T) {
+	type TT struct {
+		description string
+		stub        sq.SelectQuery
+		q           sq.SelectQuery
+	}
+	u := USERS().As("u")
+	ids := make([]int, 1001)
+	uuid1, uuid2 := "5e0e1d3a-8e2b-4ac4-9f3e-2b1c4d6f7a80", "0b9f8c2e-7a61-4d3b-8e5f-1c2d3e4f5a6b"
+	tests := []TT{
+		{
+			"in list",
+			sq.From(u).Where(u.USER_ID.In([]int{1}), u.NAME.EqString("alice")),
+			sq.From(u).Where(u.USER_ID.In([]int{1, 2, 3}), u.NAME.EqString("bob")),
+		},
+		{
+			"array parameter",
+			sq.From(u).Where(u.USER_ID.In([]int{1}), u.USER_ID.NotIn(sq.ArrayParam([]int{1}))),
+			sq.From(u).Where(u.USER_ID.In(ids), u.USER_ID.NotIn(sq.ArrayParam([]int{1, 2}))),
+		},
+		{
+			"ANY with an array",
+			sq.From(u).Where(sq.Predicatef("? = ANY(?)", u.USER_ID, pq.Array([]int{1, 2}))),
+			sq.From(u).Where(sq.Predicatef("? = ANY(?)", u.USER_ID, pq.Array([]int{1, 2}))),
+		},
+		{
+			"uuid in list",
+			sq.From(u).Where(u.TOKEN.In([]string{uuid1})),
+			sq.From(u).Where(u.TOKEN.In([]string{uuid1, uuid2})),
+		},
+		{
+			"row values",
+			sq.From(u).Where(sq.RowValue{u.USER_ID, u.NAME}.In(sq.RowValues{{1, "a"}})),
+			sq.From(u).Where(sq.RowValue{u.USER_ID, u.NAME}.In(sq.RowValues{{1, "a"}, {2, "b"}})),
+		},
+		{
+			"string literal",
+			sq.From(u).Where(u.NAME.EqString("alice"), sq.Predicatef("u.email IN ('$1, $2')")),
+			sq.From(u).Where(u.NAME.EqString("bob"), sq.Predicatef("u.email IN ('$1, $2')")),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			var userIDs []int
+			var userID int
+			mapper := func(row *sq.Row) {
+				userID = row.Int(u.USER_ID)
+			}
+			accumulator := func() {
+				userIDs = append(userIDs, userID)
+			}
+			db := NewDB()
+			db.StubFingerprint(tt.stub.Selectx(mapper, accumulator)).Rows([]string{"user_id"}, []interface{}{1})
+			err := tt.q.Selectx(mapper, accumulator).Fetch(db)
+			is.NoErr(err)
+			is.Equal([]int{1}, userIDs)
+		})
+	}
+}
+
+func TestDB_StubFingerprint_ownQuery(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	var n int
+	// an IN list outside of the WHERE clause
+	q := sq.From(u).SelectRowx(func(row *sq.Row) {
+		n = row.Int(sq.NumberFieldf("CASE WHEN ? IN (?) THEN 1 END", u.USER_ID, []int{1, 2}))
+	})
+	db := NewDB()
+	db.StubFingerprint(q).Rows([]string{"n"}, []interface{}{1})
+	err := q.Fetch(db)
+	is.NoErr(err)
+	is.Equal(1, n)
+}
@@ -14,14 +14,23 @@ func NewEnumField(name string, table Table) EnumField {
 type StringField struct {
 	// StringField will be one of the following:
 
-	// 1) Literal string value
+	// 1) String expression
+	// Examples of string expressions:
+	// | query              | args        |
+	// |--------------------|-------------|
+	// | LOWER(users.email) |             |
+	// | ts_headline(?, ?)  | body, query |
+	format *string
+	values []interface{}
+
+	// 2) Literal string value
 	// Examples of literal string values:
 	// | query | args |
 	// |-------|------|
 	// | ?     | abcd |
 	value *string
 
-	// 2) String column
+	// 3) String column
 	// Examples of boolean columns:
 	// | query       | args |
 	// |-------------|------|
@@ -39,12 +48,15 @@ type StringField struct {
 // described in the StringField internal struct comments.
 func (f StringField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
 	switch {
+	case f.format != nil:
+		// 1) String expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
 	case f.value != nil:
-		// 1) Literal string value
+		// 2) Literal string value
 		buf.WriteString("?")
 		*args = append(*args, *f.value)
 	default:
-		// 2) String column
+		// 3) String column
 		tableQualifier := f.table.GetAlias()
 		if tableQualifier == "" {
 			tableQualifier = f.table.GetName()
@@ -304,8 +316,15 @@ func (f StringField) NotILikeString(s string) Predicate {
 	}
 }
 
-// In returns an 'X IN (Y)' Predicate.
+// In returns an 'X IN (Y)' Predicate. If Y is an ArrayParameter or a slice of
+// more than 1000 elements, it is bound as a single array i.e. 'X = ANY($1)'.
 func (f StringField) In(v interface{}) Predicate {
+	if p, ok := arrayParam(v); ok {
+		return CustomPredicate{
+			Format: "? = ANY(?)",
+			Values: []interface{}{f, p},
+		}
+	}
 	var format string
 	var values []interface{}
 	switch v := v.(type) {
@@ -325,6 +344,35 @@ func (f StringField) In(v interface{}) Predicate {
 	}
 }
 
+// NotIn returns an 'X NOT IN (Y)' Predicate. If Y is an ArrayParameter or a
+// slice of more than 1000 elements, it is bound as a single array i.e.
+// 'X <> ALL($1)'.
+func (f StringField) NotIn(v interface{}) Predicate {
+	if p, ok := arrayParam(v); ok {
+		return CustomPredicate{
+			Format: "? <> ALL(?)",
+			Values: []interface{}{f, p},
+		}
+	}
+	var format string
+	var values []interface{}
+	switch v := v.(type) {
+	case RowValue:
+		format = "? NOT IN ?"
+		values = []interface{}{f, v}
+	case Query:
+		format = "? NOT IN (?)"
+		values = []interface{}{f, v.NestThis()}
+	default:
+		format = "? NOT IN (?)"
+		values = []interface{}{f, v}
+	}
+	return CustomPredicate{
+		Format: format,
+		Values: values,
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a StringField.
 func (f StringField) String() string {
@@ -345,3 +393,11 @@ func (f StringField) GetAlias() string {
 func (f StringField) GetName() string {
 	return f.name
 }
+
+// StringFieldf creates a new string expression.
+func StringFieldf(format string, values ...interface{}) StringField {
+	return StringField{
+		format: &format,
+		values: values,
+	}
+}
@@ -0,0 +1,169 @@
+package sq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Concat represents the CONCAT() function, which concatenates the StringField
+// with each value (a field or a Go string). NULL values are ignored. Values
+// that are not fields are cast to TEXT, as postgres cannot infer the type of a
+// parameter passed to CONCAT.
+func (f StringField) Concat(values ...interface{}) StringField {
+	buf := &strings.Builder{}
+	buf.WriteString("CONCAT(?")
+	for _, value := range values {
+		switch value.(type) {
+		case Field, Query:
+			buf.WriteString(", ?")
+		default:
+			buf.WriteString(", ?::TEXT")
+		}
+	}
+	buf.WriteString(")")
+	return StringFieldf(buf.String(), append([]interface{}{f}, values...)...)
+}
+
+// Lower represents the LOWER() function.
+func (f StringField) Lower() StringField {
+	return StringFieldf("LOWER(?)", f)
+}
+
+// Upper represents the UPPER() function.
+func (f StringField) Upper() StringField {
+	return StringFieldf("UPPER(?)", f)
+}
+
+// Trim represents the TRIM() function, which removes leading and trailing
+// whitespace.
+func (f StringField) Trim() StringField {
+	return StringFieldf("TRIM(?)", f)
+}
+
+// Substring represents the SUBSTRING(X FROM start FOR length) function. The
+// start is 1-indexed. If length is negative the rest of the string is taken
+// i.e. 'SUBSTRING(X FROM start)'.
+func (f StringField) Substring(start, length int) StringField {
+	if length < 0 {
+		return StringFieldf("SUBSTRING(? FROM ?)", f, start)
+	}
+	return StringFieldf("SUBSTRING(? FROM ? FOR ?)", f, start, length)
+}
+
+// Replace represents the REPLACE() function, which replaces every occurrence
+// of from with to. Both may be a field or a Go string.
+func (f StringField) Replace(from, to interface{}) StringField {
+	return StringFieldf("REPLACE(?, ?, ?)", f, from, to)
+}
+
+// Left represents the LEFT() function, which returns the first n characters.
+func (f StringField) Left(n int) StringField {
+	return StringFieldf("LEFT(?, ?)", f, n)
+}
+
+// Right represents the RIGHT() function, which returns the last n characters.
+func (f StringField) Right(n int) StringField {
+	return StringFieldf("RIGHT(?, ?)", f, n)
+}
+
+// LPad represents the LPAD() function, which left pads the StringField to the
+// length with fill (a field or a Go string).
+func (f StringField) LPad(length int, fill interface{}) StringField {
+	return StringFieldf("LPAD(?, ?, ?)", f, length, fill)
+}
+
+// RPad represents the RPAD() function, which right pads the StringField to the
+// length with fill (a field or a Go string).
+func (f StringField) RPad(length int, fill interface{}) StringField {
+	return StringFieldf("RPAD(?, ?, ?)", f, length, fill)
+}
+
+// Length represents the LENGTH() function, which returns the number of
+// characters in the StringField.
+func (f StringField) Length() NumberField {
+	format := "LENGTH(?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{f},
+	}
+}
+
+// Position represents the POSITION(substring IN X) function, which returns the
+// 1-indexed location of the substring (0 if it is not present).
+func (f StringField) Position(substring interface{}) NumberField {
+	format := "POSITION(? IN ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{substring, f},
+	}
+}
+
+// Matches returns an 'X ~ pattern' Predicate, which matches the POSIX regular
+// expression case sensitively.
+func (f StringField) Matches(pattern interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? ~ ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// IMatches returns an 'X ~* pattern' Predicate, which matches the POSIX
+// regular expression case insensitively.
+func (f StringField) IMatches(pattern interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? ~* ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// NotMatches returns an 'X !~ pattern' Predicate.
+func (f StringField) NotMatches(pattern interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? !~ ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// NotIMatches returns an 'X !~* pattern' Predicate.
+func (f StringField) NotIMatches(pattern interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? !~* ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// SimilarTo returns an 'X SIMILAR TO pattern' Predicate.
+func (f StringField) SimilarTo(pattern interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? SIMILAR TO ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// NotSimilarTo returns an 'X NOT SIMILAR TO pattern' Predicate.
+func (f StringField) NotSimilarTo(pattern interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? NOT SIMILAR TO ?",
+		Values: []interface{}{f, pattern},
+	}
+}
+
+// RegexpReplace represents the regexp_replace() function. If flags is empty it
+// is omitted, otherwise it is passed along e.g. 'g' to replace every match.
+func (f StringField) RegexpReplace(pattern, replacement interface{}, flags string) StringField {
+	if flags == "" {
+		return StringFieldf("regexp_replace(?, ?, ?)", f, pattern, replacement)
+	}
+	return StringFieldf("regexp_replace(?, ?, ?, ?)", f, pattern, replacement, flags)
+}
+
+// Collate returns a new StringField with the collation applied i.e. 'X
+// COLLATE "name"', which affects both comparison and ordering. It panics if
+// the collation name contains a double quote, because the name cannot be
+// passed as an argument.
+func (f StringField) Collate(name string) StringField {
+	if name == "" || strings.ContainsAny(name, "\"\x00") {
+		panic(fmt.Errorf("sq: invalid collation %q", name))
+	}
+	return StringFieldf(`? COLLATE "`+name+`"`, f)
+}
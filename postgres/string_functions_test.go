@@ -0,0 +1,99 @@
+package sq
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestStringFunctions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	tests := []TT{
+		{"Concat", u.DISPLAYNAME.Concat(" <", u.EMAIL, ">"), nil, "CONCAT(u.displayname, ?::TEXT, u.email, ?::TEXT)", []interface{}{" <", ">"}},
+		{"Lower", u.EMAIL.Lower(), []string{"u"}, "LOWER(email)", nil},
+		{"Upper Trim", u.EMAIL.Trim().Upper(), nil, "UPPER(TRIM(u.email))", nil},
+		{"Substring", u.EMAIL.Substring(2, 3), nil, "SUBSTRING(u.email FROM ? FOR ?)", []interface{}{2, 3}},
+		{"Substring to the end", u.EMAIL.Substring(2, -1), nil, "SUBSTRING(u.email FROM ?)", []interface{}{2}},
+		{"Replace", u.EMAIL.Replace("@", u.DISPLAYNAME), nil, "REPLACE(u.email, ?, u.displayname)", []interface{}{"@"}},
+		{"Left", u.EMAIL.Left(3), nil, "LEFT(u.email, ?)", []interface{}{3}},
+		{"Right", u.EMAIL.Right(3), nil, "RIGHT(u.email, ?)", []interface{}{3}},
+		{"LPad", u.DISPLAYNAME.LPad(10, "*"), nil, "LPAD(u.displayname, ?, ?)", []interface{}{10, "*"}},
+		{"RPad", u.DISPLAYNAME.RPad(10, u.EMAIL), nil, "RPAD(u.displayname, ?, u.email)", []interface{}{10}},
+		{"Length", u.EMAIL.Length(), nil, "LENGTH(u.email)", nil},
+		{"Position", u.EMAIL.Position("@"), nil, "POSITION(? IN u.email)", []interface{}{"@"}},
+		{"RegexpReplace", u.EMAIL.RegexpReplace("[0-9]+", "#", ""), nil, "regexp_replace(u.email, ?, ?)", []interface{}{"[0-9]+", "#"}},
+		{"RegexpReplace flags", u.EMAIL.RegexpReplace("[0-9]+", "#", "g"), nil, "regexp_replace(u.email, ?, ?, ?)", []interface{}{"[0-9]+", "#", "g"}},
+		{"Collate", u.DISPLAYNAME.Collate("C").Desc(), nil, `u.displayname COLLATE "C" DESC`, nil},
+		{"Matches", u.EMAIL.Matches("^a"), nil, "u.email ~ ?", []interface{}{"^a"}},
+		{"IMatches", u.EMAIL.IMatches("^a"), nil, "u.email ~* ?", []interface{}{"^a"}},
+		{"NotMatches", u.EMAIL.NotMatches("^a"), nil, "u.email !~ ?", []interface{}{"^a"}},
+		{"NotIMatches", u.EMAIL.NotIMatches(u.DISPLAYNAME), nil, "u.email !~* u.displayname", nil},
+		{"SimilarTo", u.EMAIL.SimilarTo("%(a|b)%"), nil, "u.email SIMILAR TO ?", []interface{}{"%(a|b)%"}},
+		{"NotSimilarTo", u.EMAIL.NotSimilarTo("%(a|b)%"), nil, "u.email NOT SIMILAR TO ?", []interface{}{"%(a|b)%"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestStringFunctions_Queries(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	gotQuery, gotArgs := From(u).
+		Where(u.EMAIL.Lower().EqString("bob@example.com")).
+		OrderBy(u.DISPLAYNAME.Collate("C")).
+		Select(u.DISPLAYNAME.Upper().As("name"), u.EMAIL.Length()).
+		ToSQL()
+	is.Equal(`SELECT UPPER(u.displayname) AS name, LENGTH(u.email) FROM public.users AS u`+
+		` WHERE LOWER(u.email) = $1 ORDER BY u.displayname COLLATE "C"`, gotQuery)
+	is.Equal([]interface{}{"bob@example.com"}, gotArgs)
+
+	gotQuery, gotArgs = Update(u).Set(u.EMAIL.Set(u.EMAIL.Trim().Lower())).Where(u.EMAIL.IMatches("^\\s")).ToSQL()
+	is.Equal("UPDATE public.users AS u SET email = LOWER(TRIM(email)) WHERE u.email ~* $1", gotQuery)
+	is.Equal([]interface{}{"^\\s"}, gotArgs)
+
+	defer func() { is.True(recover() != nil) }()
+	u.DISPLAYNAME.Collate(`C" DESC; DROP TABLE users; --`)
+}
+
+func TestStringFunctions_Fetch(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "StringFunctions_Fetch")
+	is.NoErr(err)
+	defer db.Close()
+	u := USERS().As("u")
+	var displayname, email, name string
+	err = WithDB(db).
+		From(u).
+		OrderBy(u.USER_ID).
+		Limit(1).
+		SelectRowx(func(row *Row) {
+			displayname = row.String(u.DISPLAYNAME)
+			email = row.String(u.EMAIL)
+			name = row.String(u.DISPLAYNAME.Concat(" <", u.EMAIL, ">"))
+		}).
+		Fetch(nil)
+	is.NoErr(err)
+	is.Equal(displayname+" <"+email+">", name)
+}
@@ -2,8 +2,6 @@ package sq
 
 import (
 	"database/sql"
-	"database/sql/driver"
-	"encoding/json"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -33,8 +31,12 @@ func expandValues(buf *strings.Builder, args *[]interface{}, excludedTableQualif
 
 // appendSQLValue will write the SQL representation of the interface{} value
 // into the buffer and args slice. It propagates excludedTableQualifiers where
-// relevant.
+// relevant. Lists of values are collapsed into a single placeholder if the
+// query is being rendered by Fingerprint.
 func appendSQLValue(buf *strings.Builder, args *[]interface{}, excludedTableQualifiers []string, value interface{}) {
+	if fingerprinting(args) && appendCollapsedList(buf, args, value) {
+		return
+	}
 	switch v := value.(type) {
 	case nil:
 		buf.WriteString("NULL")
@@ -96,49 +98,16 @@ func randomString(n int) string {
 	return sb.String()
 }
 
-// TODO: write a version that takes in a buffer and writes into it instead
+// interpolateSQLValue returns the SQL representation of arg for display in
+// logs. It escapes arg the same way as Interpolate, but falls back to
+// printing arg with fmt.Sprintf if arg cannot be represented in SQL.
 func interpolateSQLValue(arg interface{}) string {
-	var str string // str is the SQL string representation of arg
-	switch v := arg.(type) {
-	case nil:
-		str = "NULL"
-	case bool:
-		if v {
-			str = "TRUE"
-		} else {
-			str = "FALSE"
-		}
-	case string:
-		str = "'" + v + "'"
-	case int, int8, int16, int32, int64,
-		uint, uint8, uint16, uint32, uint64,
-		float32, float64:
-		str = fmt.Sprint(arg)
-	case time.Time:
-		str = "'" + v.Format(time.RFC3339Nano) + "'"
-	case driver.Valuer:
-		Interface, err := v.Value()
-		if err != nil {
-			str = ":" + err.Error() + ":"
-		} else {
-			switch Concrete := Interface.(type) {
-			case string:
-				str = "'" + Concrete + "'"
-			case nil:
-				str = "NULL"
-			default:
-				str = ":" + fmt.Sprintf("%#v", arg) + ":" // give up, don't know what it is, resort to fmt.Sprintf
-			}
-		}
-	default:
-		b, err := json.Marshal(arg)
-		if err != nil {
-			str = ":" + fmt.Sprintf("%#v", arg) + ":" // give up, don't know what it is, resort to fmt.Sprintf
-		} else {
-			str = "'" + string(b) + "'"
-		}
+	buf := &strings.Builder{}
+	err := interpolateValue(buf, time.UTC, arg)
+	if err != nil {
+		return ":" + fmt.Sprintf("%#v", arg) + ":" // give up, don't know what it is, resort to fmt.Sprintf
 	}
-	return str
+	return buf.String()
 }
 
 // AppendSQLDisplay
@@ -211,9 +180,8 @@ func questionToDollarPlaceholders(buf *strings.Builder, query string) {
 }
 
 // questionInterpolate interpolates the question mark ? placeholders in a query
-// string with the args in the args slice. It is vulnerable to SQL injection
-// and should be used for display purposes only, not for actually running
-// against a database.
+// string with the args in the args slice. It is meant for display purposes,
+// use Interpolate to get SQL that can be run against a database.
 func questionInterpolate(query string, args ...interface{}) string {
 	buf := &strings.Builder{}
 	// i is the position of the ? in the query
@@ -233,19 +201,21 @@ func questionInterpolate(query string, args ...interface{}) string {
 }
 
 // dollarInterpolate interpolates the dollar $1 ($2, $3 etc) placeholders in a
-// query string with the args in the args slice. It is vulnerable to SQL
-// injection and should be used for display purposes only, not for actually
-// running against a database.
+// query string with the args in the args slice. It is meant for display
+// purposes, use Interpolate to get SQL that can be run against a database.
 func dollarInterpolate(query string, args ...interface{}) string {
-	oldnewSets := make(map[int][]string)
-	for i, arg := range args {
-		str := interpolateSQLValue(arg)
-		placeholder := "$" + strconv.Itoa(i+1)
-		oldnewSets[len(placeholder)] = append(oldnewSets[len(placeholder)], placeholder, str)
-	}
-	result := query
-	for i := len(oldnewSets) + 1; i >= 2; i-- {
-		result = strings.NewReplacer(oldnewSets[i]...).Replace(result)
+	result, err := Interpolate(query, args)
+	if err != nil {
+		oldnewSets := make(map[int][]string)
+		for i, arg := range args {
+			str := interpolateSQLValue(arg)
+			placeholder := "$" + strconv.Itoa(i+1)
+			oldnewSets[len(placeholder)] = append(oldnewSets[len(placeholder)], placeholder, str)
+		}
+		result = query
+		for i := len(oldnewSets) + 1; i >= 2; i-- {
+			result = strings.NewReplacer(oldnewSets[i]...).Replace(result)
+		}
 	}
 	return result
 }
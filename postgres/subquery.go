@@ -1,6 +1,9 @@
 package sq
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // Subquery represents an SQL subquery.
 type Subquery map[string]CustomField
@@ -150,3 +153,64 @@ func (subq Subquery) GetAlias() string {
 func (subq Subquery) NestThis() Query {
 	return subq
 }
+
+// column returns the name of the Subquery column. It panics if the Subquery
+// does not have that column.
+func (subq Subquery) column(name string) string {
+	switch name {
+	case metadataQuery, metadataAlias:
+	default:
+		if _, ok := subq[name]; ok {
+			return name
+		}
+	}
+	panic(fmt.Errorf("sq: Subquery %s has no column %s", subq.GetAlias(), name))
+}
+
+// NumberField returns the Subquery column as a NumberField. It panics if the
+// Subquery does not have that column.
+func (subq Subquery) NumberField(column string) NumberField {
+	return NewNumberField(subq.column(column), subq)
+}
+
+// StringField returns the Subquery column as a StringField. It panics if the
+// Subquery does not have that column.
+func (subq Subquery) StringField(column string) StringField {
+	return NewStringField(subq.column(column), subq)
+}
+
+// BooleanField returns the Subquery column as a BooleanField. It panics if the
+// Subquery does not have that column.
+func (subq Subquery) BooleanField(column string) BooleanField {
+	return NewBooleanField(subq.column(column), subq)
+}
+
+// TimeField returns the Subquery column as a TimeField. It panics if the
+// Subquery does not have that column.
+func (subq Subquery) TimeField(column string) TimeField {
+	return NewTimeField(subq.column(column), subq)
+}
+
+// JSONField returns the Subquery column as a JSONField. It panics if the
+// Subquery does not have that column.
+func (subq Subquery) JSONField(column string) JSONField {
+	return NewJSONField(subq.column(column), subq)
+}
+
+// BinaryField returns the Subquery column as a BinaryField. It panics if the
+// Subquery does not have that column.
+func (subq Subquery) BinaryField(column string) BinaryField {
+	return NewBinaryField(subq.column(column), subq)
+}
+
+// UUIDField returns the Subquery column as a UUIDField. It panics if the
+// Subquery does not have that column.
+func (subq Subquery) UUIDField(column string) UUIDField {
+	return NewUUIDField(subq.column(column), subq)
+}
+
+// ArrayField returns the Subquery column as an ArrayField. It panics if the
+// Subquery does not have that column.
+func (subq Subquery) ArrayField(column string) ArrayField {
+	return NewArrayField(subq.column(column), subq)
+}
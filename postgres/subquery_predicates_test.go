@@ -0,0 +1,136 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSubqueryPredicates(t *testing.T) {
+	type TT struct {
+		description string
+		p           Predicate
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	admins := From(ur).Where(ur.ROLE.EqString("admin")).Select(ur.USER_ID)
+	tests := []TT{
+		{
+			"RowValue In RowValues",
+			RowValue{u.USER_ID, u.DISPLAYNAME}.In(RowValues{{1, "a"}, {2, "b"}}),
+			"(u.user_id, u.displayname) IN ((?, ?), (?, ?))",
+			[]interface{}{1, "a", 2, "b"},
+		},
+		{
+			"RowValue In Query",
+			RowValue{u.USER_ID, u.DISPLAYNAME}.In(From(ur).Select(ur.USER_ID, ur.ROLE)),
+			"(u.user_id, u.displayname) IN (SELECT ur.user_id, ur.role FROM public.user_roles AS ur)",
+			nil,
+		},
+		{
+			"RowValue In RowValue",
+			RowValue{u.USER_ID, u.DISPLAYNAME}.In(RowValue{1, "a"}),
+			"(u.user_id, u.displayname) IN ((?, ?))",
+			[]interface{}{1, "a"},
+		},
+		{
+			"single element RowValue NotIn RowValue",
+			RowValue{u.USER_ID}.NotIn(RowValue{1, 2}),
+			"(u.user_id) NOT IN (?, ?)",
+			[]interface{}{1, 2},
+		},
+		{
+			"RowValue NotIn RowValue",
+			RowValue{u.USER_ID, u.DISPLAYNAME}.NotIn(RowValue{1, "a"}),
+			"(u.user_id, u.displayname) NOT IN ((?, ?))",
+			[]interface{}{1, "a"},
+		},
+		{
+			"RowValue Gt RowValue",
+			RowValue{u.DISPLAYNAME, u.USER_ID}.Gt(RowValue{"bob", 5}),
+			"(u.displayname, u.user_id) > (?, ?)",
+			[]interface{}{"bob", 5},
+		},
+		{
+			"RowValue Eq Query",
+			RowValue{u.USER_ID, u.DISPLAYNAME}.Eq(From(ur).Where(ur.USER_ROLE_ID.EqInt(1)).Select(ur.USER_ID, ur.ROLE)),
+			"(u.user_id, u.displayname) = (SELECT ur.user_id, ur.role FROM public.user_roles AS ur WHERE ur.user_role_id = ?)",
+			[]interface{}{1},
+		},
+		{
+			"RowValue Ne AllOf",
+			RowValue{u.USER_ID, u.DISPLAYNAME}.Ne(AllOf(From(ur).Select(ur.USER_ID, ur.ROLE))),
+			"(u.user_id, u.displayname) <> ALL (SELECT ur.user_id, ur.role FROM public.user_roles AS ur)",
+			nil,
+		},
+		{"RowValue Ge", RowValue{u.USER_ID}.Ge(RowValue{1}), "(u.user_id) >= (?)", []interface{}{1}},
+		{"RowValue Lt", RowValue{u.USER_ID}.Lt(RowValue{1}), "(u.user_id) < (?)", []interface{}{1}},
+		{"RowValue Le", RowValue{u.USER_ID}.Le(RowValue{1}), "(u.user_id) <= (?)", []interface{}{1}},
+		{
+			"Eq AnyOf",
+			Eq(u.USER_ID, AnyOf(admins)),
+			"u.user_id = ANY (SELECT ur.user_id FROM public.user_roles AS ur WHERE ur.role = ?)",
+			[]interface{}{"admin"},
+		},
+		{
+			"Gt AllOf",
+			Gt(u.USER_ID, AllOf(admins)),
+			"u.user_id > ALL (SELECT ur.user_id FROM public.user_roles AS ur WHERE ur.role = ?)",
+			[]interface{}{"admin"},
+		},
+		{
+			"NumberField NotIn Query",
+			u.USER_ID.NotIn(admins),
+			"u.user_id NOT IN (SELECT ur.user_id FROM public.user_roles AS ur WHERE ur.role = ?)",
+			[]interface{}{"admin"},
+		},
+		{
+			"StringField NotIn slice",
+			u.DISPLAYNAME.NotIn([]string{"a", "b"}),
+			"u.displayname NOT IN (?, ?)",
+			[]interface{}{"a", "b"},
+		},
+		{
+			"NotExists",
+			NotExists(From(ur).Where(ur.USER_ID.Eq(u.USER_ID), ur.ROLE.EqString("admin")).SelectOne()),
+			"NOT EXISTS(SELECT 1 FROM public.user_roles AS ur WHERE ur.user_id = u.user_id AND ur.role = ?)",
+			[]interface{}{"admin"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.p.AppendSQLExclude(buf, &args, nil, nil)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSubqueryPredicates_Placeholders(t *testing.T) {
+	is := is.New(t)
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	roles := From(ur).Where(ur.ROLE.EqString("admin")).Select(ur.USER_ID, ur.ROLE)
+	gotQuery, gotArgs := From(u).
+		Where(
+			u.EMAIL.LikeString("%@gmail.com"),
+			Exists(From(ur).Where(ur.USER_ID.Eq(u.USER_ID), ur.COHORT.EqString("2020")).SelectOne()),
+			RowValue{u.USER_ID, u.DISPLAYNAME}.NotIn(roles),
+			u.USER_ID.Gt(NumberFieldf("?", 0)),
+			NotExists(From(ur).Where(ur.ROLE.EqString("banned")).SelectOne()),
+		).
+		Select(u.USER_ID).
+		ToSQL()
+	is.Equal("SELECT u.user_id FROM public.users AS u WHERE u.email LIKE $1"+
+		" AND EXISTS(SELECT 1 FROM public.user_roles AS ur WHERE ur.user_id = u.user_id AND ur.cohort = $2)"+
+		" AND (u.user_id, u.displayname) NOT IN (SELECT ur.user_id, ur.role FROM public.user_roles AS ur WHERE ur.role = $3)"+
+		" AND u.user_id > $4"+
+		" AND NOT EXISTS(SELECT 1 FROM public.user_roles AS ur WHERE ur.role = $5)", gotQuery)
+	is.Equal([]interface{}{"%@gmail.com", "2020", "admin", 0, "banned"}, gotArgs)
+}
@@ -124,6 +124,19 @@ func TestSubquery(t *testing.T) {
 			tt.wantArgs = []interface{}{1, "apple", 2, 3}
 			return tt
 		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Subquery typed columns"
+			u := USERS().As("u")
+			subq := Select(u.USER_ID, u.DISPLAYNAME, Count().As("count")).From(u).GroupBy(u.USER_ID, u.DISPLAYNAME).Subquery("subq")
+			count := subq.NumberField("count")
+			tt.q = Select(subq.NumberField("user_id"), count).From(subq).Where(subq.StringField("displayname").EqString("bob")).OrderBy(count.Desc())
+			tt.wantQuery = "SELECT subq.user_id, subq.count FROM" +
+				" (SELECT u.user_id, u.displayname, COUNT(*) AS count FROM public.users AS u GROUP BY u.user_id, u.displayname) AS subq" +
+				" WHERE subq.displayname = $1 ORDER BY subq.count DESC"
+			tt.wantArgs = []interface{}{"bob"}
+			return tt
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -136,3 +149,11 @@ func TestSubquery(t *testing.T) {
 		})
 	}
 }
+
+func TestSubquery_TypedColumnPanics(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	subq := Select(u.USER_ID).From(u).Subquery("subq")
+	defer func() { is.True(recover() != nil) }()
+	subq.StringField("email")
+}
@@ -0,0 +1,87 @@
+package sq
+
+// textSearchFunction builds a text search function call where the text search
+// configuration is optional i.e. 'name(?::regconfig, ?)' or 'name(?)'.
+func textSearchFunction(name string, config string, value interface{}) (format string, values []interface{}) {
+	if config == "" {
+		return name + "(?)", []interface{}{value}
+	}
+	return name + "(?::regconfig, ?)", []interface{}{config, value}
+}
+
+// ToTSVector represents the to_tsvector() function. If config is empty the
+// database's default_text_search_config is used.
+func ToTSVector(config string, document interface{}) TSVectorField {
+	format, values := textSearchFunction("to_tsvector", config, document)
+	return TSVectorField{
+		format: &format,
+		values: values,
+	}
+}
+
+// ToTSQuery represents the to_tsquery() function. If config is empty the
+// database's default_text_search_config is used.
+func ToTSQuery(config string, query interface{}) TSQueryField {
+	format, values := textSearchFunction("to_tsquery", config, query)
+	return TSQueryField{
+		format: &format,
+		values: values,
+	}
+}
+
+// PlainToTSQuery represents the plainto_tsquery() function. If config is empty
+// the database's default_text_search_config is used.
+func PlainToTSQuery(config string, query interface{}) TSQueryField {
+	format, values := textSearchFunction("plainto_tsquery", config, query)
+	return TSQueryField{
+		format: &format,
+		values: values,
+	}
+}
+
+// WebsearchToTSQuery represents the websearch_to_tsquery() function. If config
+// is empty the database's default_text_search_config is used.
+func WebsearchToTSQuery(config string, query interface{}) TSQueryField {
+	format, values := textSearchFunction("websearch_to_tsquery", config, query)
+	return TSQueryField{
+		format: &format,
+		values: values,
+	}
+}
+
+// TSRank represents the ts_rank() function.
+func TSRank(vector TSVectorField, query TSQueryField) NumberField {
+	format := "ts_rank(?, ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{vector, query},
+	}
+}
+
+// TSRankCD represents the ts_rank_cd() function.
+func TSRankCD(vector TSVectorField, query TSQueryField) NumberField {
+	format := "ts_rank_cd(?, ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{vector, query},
+	}
+}
+
+// TSHeadline represents the ts_headline() function. If config is empty the
+// database's default_text_search_config is used. If options is empty the
+// default headline options are used, otherwise it is passed along as the
+// options string e.g. 'MaxWords=10, MinWords=5'.
+func TSHeadline(config string, document interface{}, query TSQueryField, options string) StringField {
+	format, values := textSearchFunction("ts_headline", config, document)
+	format = format[:len(format)-1] + ", ?"
+	values = append(values, query)
+	if options != "" {
+		format += ", ?"
+		values = append(values, options)
+	}
+	format += ")"
+	return StringField{
+		format: &format,
+		values: values,
+	}
+}
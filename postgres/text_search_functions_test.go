@@ -0,0 +1,114 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestTextSearchFunctions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u := USERS().As("u")
+	vector := ToTSVector("english", u.DISPLAYNAME)
+	tests := []TT{
+		{
+			"ToTSVector",
+			ToTSVector("", u.DISPLAYNAME),
+			nil,
+			"to_tsvector(u.displayname)",
+			nil,
+		},
+		{
+			"ToTSVector with config",
+			vector,
+			nil,
+			"to_tsvector(?::regconfig, u.displayname)",
+			[]interface{}{"english"},
+		},
+		{
+			"ToTSQuery",
+			ToTSQuery("simple", "fat:*"),
+			nil,
+			"to_tsquery(?::regconfig, ?)",
+			[]interface{}{"simple", "fat:*"},
+		},
+		{
+			"PlainToTSQuery",
+			PlainToTSQuery("", "fat rats"),
+			nil,
+			"plainto_tsquery(?)",
+			[]interface{}{"fat rats"},
+		},
+		{
+			"WebsearchToTSQuery",
+			WebsearchToTSQuery("english", `"fat rat" -cat`),
+			nil,
+			"websearch_to_tsquery(?::regconfig, ?)",
+			[]interface{}{"english", `"fat rat" -cat`},
+		},
+		{
+			"TSRank",
+			TSRank(vector, TSQuery("fat")),
+			nil,
+			"ts_rank(to_tsvector(?::regconfig, u.displayname), ?::tsquery)",
+			[]interface{}{"english", "fat"},
+		},
+		{
+			"TSRankCD",
+			TSRankCD(vector, TSQuery("fat")),
+			[]string{"u"},
+			"ts_rank_cd(to_tsvector(?::regconfig, displayname), ?::tsquery)",
+			[]interface{}{"english", "fat"},
+		},
+		{
+			"TSHeadline",
+			TSHeadline("", u.DISPLAYNAME, TSQuery("fat"), ""),
+			nil,
+			"ts_headline(u.displayname, ?::tsquery)",
+			[]interface{}{"fat"},
+		},
+		{
+			"TSHeadline with config and options",
+			TSHeadline("english", u.DISPLAYNAME, TSQuery("fat"), "MaxWords=10"),
+			nil,
+			"ts_headline(?::regconfig, u.displayname, ?::tsquery, ?)",
+			[]interface{}{"english", "fat", "MaxWords=10"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestTextSearchFunctions_Select(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	vector := ToTSVector("english", u.DISPLAYNAME)
+	query := WebsearchToTSQuery("english", "fat rat")
+	gotQuery, gotArgs := From(u).
+		Where(vector.Matches(query)).
+		OrderBy(TSRank(vector, query).Desc()).
+		Select(u.USER_ID, TSHeadline("english", u.DISPLAYNAME, query, "").As("headline")).
+		ToSQL()
+	is.Equal("SELECT u.user_id, ts_headline($1::regconfig, u.displayname, websearch_to_tsquery($2::regconfig, $3)) AS headline"+
+		" FROM public.users AS u"+
+		" WHERE to_tsvector($4::regconfig, u.displayname) @@ websearch_to_tsquery($5::regconfig, $6)"+
+		" ORDER BY ts_rank(to_tsvector($7::regconfig, u.displayname), websearch_to_tsquery($8::regconfig, $9)) DESC", gotQuery)
+	is.Equal([]interface{}{"english", "english", "fat rat", "english", "english", "fat rat", "english", "english", "fat rat"}, gotArgs)
+}
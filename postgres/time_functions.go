@@ -0,0 +1,178 @@
+package sq
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Now represents the NOW() function.
+func Now() TimeField {
+	format := "NOW()"
+	return TimeField{
+		format: &format,
+	}
+}
+
+// CurrentDate represents the CURRENT_DATE function.
+func CurrentDate() TimeField {
+	format := "CURRENT_DATE"
+	return TimeField{
+		format: &format,
+	}
+}
+
+// Interval represents a span of time made up of calendar units (years, months
+// and days, whose lengths vary) followed by an exact time.Duration.
+type Interval struct {
+	Years    int
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// String returns the Interval in the Postgres interval input format e.g. '1
+// years 2 months 3 days 3600 seconds'.
+func (i Interval) String() string {
+	var parts []string
+	if i.Years != 0 {
+		parts = append(parts, strconv.Itoa(i.Years)+" years")
+	}
+	if i.Months != 0 {
+		parts = append(parts, strconv.Itoa(i.Months)+" months")
+	}
+	if i.Days != 0 {
+		parts = append(parts, strconv.Itoa(i.Days)+" days")
+	}
+	if i.Duration != 0 || len(parts) == 0 {
+		parts = append(parts, strconv.FormatFloat(i.Duration.Seconds(), 'f', -1, 64)+" seconds")
+	}
+	return strings.Join(parts, " ")
+}
+
+// AddInterval returns a new TimeField with the Interval added to it i.e. '(X +
+// interval)'.
+func (f TimeField) AddInterval(interval Interval) TimeField {
+	format := "(? + ?::interval)"
+	return TimeField{
+		format: &format,
+		values: []interface{}{f, interval.String()},
+	}
+}
+
+// SubInterval returns a new TimeField with the Interval subtracted from it i.e.
+// '(X - interval)'.
+func (f TimeField) SubInterval(interval Interval) TimeField {
+	format := "(? - ?::interval)"
+	return TimeField{
+		format: &format,
+		values: []interface{}{f, interval.String()},
+	}
+}
+
+// AddDuration returns a new TimeField with the time.Duration added to it.
+func (f TimeField) AddDuration(d time.Duration) TimeField {
+	return f.AddInterval(Interval{Duration: d})
+}
+
+// SubDuration returns a new TimeField with the time.Duration subtracted from
+// it.
+func (f TimeField) SubDuration(d time.Duration) TimeField {
+	return f.SubInterval(Interval{Duration: d})
+}
+
+// DateTrunc represents the date_trunc() function, which truncates the
+// TimeField to the precision of the unit e.g. 'day' or 'month'.
+func (f TimeField) DateTrunc(unit string) TimeField {
+	format := "date_trunc(?, ?)"
+	return TimeField{
+		format: &format,
+		values: []interface{}{unit, f},
+	}
+}
+
+// Extract represents the EXTRACT(part FROM X) function e.g. Extract("year")
+// or Extract("epoch"). It panics if the part is not a valid identifier,
+// because the part cannot be passed as an argument.
+func (f TimeField) Extract(part string) NumberField {
+	format := "EXTRACT(" + timePartKeyword(part) + " FROM ?)"
+	return NumberField{
+		format: &format,
+		values: []interface{}{f},
+	}
+}
+
+// AtTimeZone returns a new TimeField converted to the time zone i.e. 'X AT
+// TIME ZONE tz'.
+func (f TimeField) AtTimeZone(tz string) TimeField {
+	format := "? AT TIME ZONE ?"
+	return TimeField{
+		format: &format,
+		values: []interface{}{f, tz},
+	}
+}
+
+// Age represents the age() function, which returns the interval between the
+// TimeField and the other TimeField i.e. 'age(X, other)'. MySQL has no
+// equivalent, so Age is only available for Postgres. Use DateDiff to get the
+// difference in a unit in both dialects.
+func (f TimeField) Age(other TimeField) CustomField {
+	return CustomField{
+		Format: "age(?, ?)",
+		Values: []interface{}{f, other},
+	}
+}
+
+// DateDiff returns the number of whole units (year, quarter, month, week, day,
+// hour, minute or second) between start and end, truncated towards zero. It is
+// the Postgres equivalent of MySQL's TIMESTAMPDIFF(unit, start, end). start
+// and end are cast to TIMESTAMP so that DATE columns can be compared as well.
+// It panics if the unit is not supported.
+func DateDiff(unit string, start, end TimeField) NumberField {
+	var format string
+	var values []interface{}
+	switch strings.ToLower(unit) {
+	case "year", "years":
+		format = "date_part('year', age(?, ?))"
+		values = []interface{}{end, start}
+	case "month", "months":
+		format = "(date_part('year', age(?, ?)) * 12 + date_part('month', age(?, ?)))"
+		values = []interface{}{end, start, end, start}
+	case "quarter", "quarters":
+		format = "trunc((date_part('year', age(?, ?)) * 12 + date_part('month', age(?, ?))) / 3)"
+		values = []interface{}{end, start, end, start}
+	default:
+		seconds, ok := map[string]int{
+			"week": 604800, "weeks": 604800,
+			"day": 86400, "days": 86400,
+			"hour": 3600, "hours": 3600,
+			"minute": 60, "minutes": 60,
+			"second": 1, "seconds": 1,
+		}[strings.ToLower(unit)]
+		if !ok {
+			panic(fmt.Errorf("sq: DateDiff does not support the unit %q", unit))
+		}
+		// DATE - DATE is an integer number of days rather than an interval
+		format = "trunc(EXTRACT(EPOCH FROM (?::TIMESTAMP - ?::TIMESTAMP)) / " + strconv.Itoa(seconds) + ")"
+		values = []interface{}{end, start}
+	}
+	return NumberField{
+		format: &format,
+		values: values,
+	}
+}
+
+// timePartKeyword returns the part as an uppercase keyword, panicking if it
+// contains anything other than letters and underscores.
+func timePartKeyword(part string) string {
+	if part == "" {
+		panic(fmt.Errorf("sq: empty time part"))
+	}
+	for _, c := range part {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_') {
+			panic(fmt.Errorf("sq: invalid time part %q", part))
+		}
+	}
+	return strings.ToUpper(part)
+}
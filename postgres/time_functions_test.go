@@ -0,0 +1,178 @@
+package sq
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestTimeFunctions(t *testing.T) {
+	type TT struct {
+		description string
+		f           Field
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	s := SESSIONS().As("s")
+	tests := []TT{
+		{"Now", Now(), nil, "NOW()", nil},
+		{"CurrentDate", CurrentDate(), nil, "CURRENT_DATE", nil},
+		{
+			"AddInterval",
+			s.CREATED_AT.AddInterval(Interval{Years: 1, Months: 2, Days: 3, Duration: 90 * time.Minute}),
+			nil,
+			"(s.created_at + ?::interval)",
+			[]interface{}{"1 years 2 months 3 days 5400 seconds"},
+		},
+		{
+			"SubInterval",
+			Now().SubInterval(Interval{Months: 1}),
+			nil,
+			"(NOW() - ?::interval)",
+			[]interface{}{"1 months"},
+		},
+		{
+			"AddDuration",
+			s.CREATED_AT.AddDuration(1500 * time.Millisecond),
+			[]string{"s"},
+			"(created_at + ?::interval)",
+			[]interface{}{"1.5 seconds"},
+		},
+		{
+			"SubDuration zero",
+			s.CREATED_AT.SubDuration(0),
+			nil,
+			"(s.created_at - ?::interval)",
+			[]interface{}{"0 seconds"},
+		},
+		{
+			"DateTrunc",
+			s.CREATED_AT.DateTrunc("month"),
+			nil,
+			"date_trunc(?, s.created_at)",
+			[]interface{}{"month"},
+		},
+		{
+			"Extract",
+			s.CREATED_AT.Extract("epoch"),
+			nil,
+			"EXTRACT(EPOCH FROM s.created_at)",
+			nil,
+		},
+		{
+			"AtTimeZone of AddInterval",
+			s.CREATED_AT.AddInterval(Interval{Days: 1}).AtTimeZone("Asia/Singapore"),
+			nil,
+			"(s.created_at + ?::interval) AT TIME ZONE ?",
+			[]interface{}{"1 days", "Asia/Singapore"},
+		},
+		{
+			"Age",
+			Now().Age(s.CREATED_AT),
+			nil,
+			"age(NOW(), s.created_at)",
+			nil,
+		},
+		{
+			"DateDiff year",
+			DateDiff("year", s.CREATED_AT, Now()),
+			nil,
+			"date_part('year', age(NOW(), s.created_at))",
+			nil,
+		},
+		{
+			"DateDiff month",
+			DateDiff("month", s.CREATED_AT, Now()),
+			nil,
+			"(date_part('year', age(NOW(), s.created_at)) * 12 + date_part('month', age(NOW(), s.created_at)))",
+			nil,
+		},
+		{
+			"DateDiff day",
+			DateDiff("DAY", s.CREATED_AT, Now()),
+			nil,
+			"trunc(EXTRACT(EPOCH FROM (NOW()::TIMESTAMP - s.created_at::TIMESTAMP)) / 86400)",
+			nil,
+		},
+		{
+			"DateDiff quarter",
+			DateDiff("quarter", s.CREATED_AT, Now()),
+			nil,
+			"trunc((date_part('year', age(NOW(), s.created_at)) * 12 + date_part('month', age(NOW(), s.created_at))) / 3)",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestTimeFunctions_Select(t *testing.T) {
+	is := is.New(t)
+	s := SESSIONS().As("s")
+	day := s.CREATED_AT.DateTrunc("day")
+	gotQuery, gotArgs := From(s).
+		Where(s.CREATED_AT.Gt(Now().SubInterval(Interval{Days: 7}))).
+		GroupBy(day).
+		Select(day.As("day"), Count()).
+		ToSQL()
+	is.Equal("SELECT date_trunc($1, s.created_at) AS day, COUNT(*)"+
+		" FROM public.sessions AS s"+
+		" WHERE s.created_at > (NOW() - $2::interval)"+
+		" GROUP BY date_trunc($3, s.created_at)", gotQuery)
+	is.Equal([]interface{}{"day", "7 days", "day"}, gotArgs)
+}
+
+func TestTimeFunctions_Fetch(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "TimeFunctions_Fetch")
+	is.NoErr(err)
+	defer db.Close()
+	start, end := TimeFieldf("DATE '2020-01-01'"), TimeFieldf("DATE '2020-07-15'")
+	var years, quarters, months, weeks, days, hours int
+	err = WithDB(db).
+		SelectRowx(func(row *Row) {
+			years = row.Int(DateDiff("year", start, end))
+			quarters = row.Int(DateDiff("quarter", start, end))
+			months = row.Int(DateDiff("month", start, end))
+			weeks = row.Int(DateDiff("week", start, end))
+			days = row.Int(DateDiff("day", start, end))
+			hours = row.Int(DateDiff("hour", start, end))
+		}).
+		Fetch(nil)
+	is.NoErr(err)
+	is.Equal(0, years)
+	is.Equal(2, quarters)
+	is.Equal(6, months)
+	is.Equal(28, weeks)
+	is.Equal(196, days)
+	is.Equal(196*24, hours)
+}
+
+func TestTimeFunctions_Panics(t *testing.T) {
+	is := is.New(t)
+	s := SESSIONS()
+	mustPanic := func(fn func()) {
+		defer func() { is.True(recover() != nil) }()
+		fn()
+	}
+	mustPanic(func() { s.CREATED_AT.Extract("year FROM NOW()); DROP TABLE users; --") })
+	mustPanic(func() { s.CREATED_AT.Extract("") })
+	mustPanic(func() { DateDiff("fortnight", s.CREATED_AT, Now()) })
+}
@@ -0,0 +1,193 @@
+package sq
+
+import "strings"
+
+// TSQueryField either represents a TSQUERY column, a tsquery expression or a
+// literal tsquery value.
+type TSQueryField struct {
+	// TSQueryField will be one of the following:
+
+	// 1) tsquery expression
+	// Examples of tsquery expressions:
+	// | query                        | args           |
+	// |------------------------------|----------------|
+	// | to_tsquery(?::regconfig, ?)  | english, fat:* |
+	// | plainto_tsquery(?)           | fat rats       |
+	// | websearch_to_tsquery(?) && ? | fat -rat, cat  |
+	format *string
+	values []interface{}
+
+	// 2) Literal tsquery value
+	// Examples of literal tsquery values:
+	// | query      | args      |
+	// |------------|-----------|
+	// | ?::tsquery | fat & rat |
+	value *string
+
+	// 3) TSQUERY column
+	// Examples of tsquery columns:
+	// | query                | args |
+	// |----------------------|------|
+	// | saved_searches.query |      |
+	// | query                |      |
+	alias string
+	table Table
+	name  string
+}
+
+// AppendSQLExclude marshals the TSQueryField into a buffer and an args slice.
+// It will not table qualify itself if its table qualifer appears in the
+// excludedTableQualifiers list.
+func (f TSQueryField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	switch {
+	case f.format != nil:
+		// 1) tsquery expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
+	case f.value != nil:
+		// 2) Literal tsquery value
+		buf.WriteString("?::tsquery")
+		*args = append(*args, *f.value)
+	default:
+		// 3) TSQUERY column
+		tableQualifier := f.table.GetAlias()
+		if tableQualifier == "" {
+			tableQualifier = f.table.GetName()
+		}
+		for _, excludedTableQualifier := range excludedTableQualifiers {
+			if tableQualifier == excludedTableQualifier {
+				tableQualifier = ""
+				break
+			}
+		}
+		if tableQualifier != "" {
+			if strings.ContainsAny(tableQualifier, " \t") {
+				buf.WriteString(`"`)
+				buf.WriteString(tableQualifier)
+				buf.WriteString(`".`)
+			} else {
+				buf.WriteString(tableQualifier)
+				buf.WriteString(".")
+			}
+		}
+		if strings.ContainsAny(f.name, " \t") {
+			buf.WriteString(`"`)
+			buf.WriteString(f.name)
+			buf.WriteString(`"`)
+		} else {
+			buf.WriteString(f.name)
+		}
+	}
+}
+
+// NewTSQueryField returns a new TSQueryField representing a TSQUERY column.
+func NewTSQueryField(name string, table Table) TSQueryField {
+	return TSQueryField{
+		name:  name,
+		table: table,
+	}
+}
+
+// TSQuery returns a new TSQueryField representing a literal tsquery value. The
+// string must already be in tsquery syntax e.g. 'fat & (rat | cat)'.
+func TSQuery(query string) TSQueryField {
+	return TSQueryField{
+		value: &query,
+	}
+}
+
+// TSQueryFieldf creates a new tsquery expression.
+func TSQueryFieldf(format string, values ...interface{}) TSQueryField {
+	return TSQueryField{
+		format: &format,
+		values: values,
+	}
+}
+
+// Set returns a FieldAssignment associating the TSQueryField to the value i.e.
+// 'field = value'.
+func (f TSQueryField) Set(value interface{}) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: value,
+	}
+}
+
+// As returns a new TSQueryField with the new field Alias i.e. 'field AS
+// Alias'.
+func (f TSQueryField) As(alias string) TSQueryField {
+	f.alias = alias
+	return f
+}
+
+// IsNull returns an 'X IS NULL' Predicate.
+func (f TSQueryField) IsNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// IsNotNull returns an 'X IS NOT NULL' Predicate.
+func (f TSQueryField) IsNotNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NOT NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// Matches returns an 'X @@ Y' Predicate. It only accepts TSVectorField.
+func (f TSQueryField) Matches(vector TSVectorField) Predicate {
+	return CustomPredicate{
+		Format: "? @@ ?",
+		Values: []interface{}{f, vector},
+	}
+}
+
+// And returns a new TSQueryField matching both TSQueryFields i.e. 'X && Y'.
+func (f TSQueryField) And(query TSQueryField) TSQueryField {
+	format := "(? && ?)"
+	return TSQueryField{
+		format: &format,
+		values: []interface{}{f, query},
+	}
+}
+
+// Or returns a new TSQueryField matching either TSQueryField i.e. 'X || Y'.
+func (f TSQueryField) Or(query TSQueryField) TSQueryField {
+	format := "(? || ?)"
+	return TSQueryField{
+		format: &format,
+		values: []interface{}{f, query},
+	}
+}
+
+// Negate returns a new TSQueryField matching the opposite of the TSQueryField
+// i.e. '!! X'.
+func (f TSQueryField) Negate() TSQueryField {
+	format := "!! ?"
+	return TSQueryField{
+		format: &format,
+		values: []interface{}{f},
+	}
+}
+
+// String implements the fmt.Stringer interface. It returns the string
+// representation of a TSQueryField.
+func (f TSQueryField) String() string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	f.AppendSQLExclude(buf, &args, nil, nil)
+	return questionInterpolate(buf.String(), args...)
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// TSQueryField.
+func (f TSQueryField) GetAlias() string {
+	return f.alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// TSQueryField.
+func (f TSQueryField) GetName() string {
+	return f.name
+}
@@ -0,0 +1,113 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestTSQueryField_AppendSQLExclude(t *testing.T) {
+	type TT struct {
+		description string
+		f           TSQueryField
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			desc := "literal value"
+			f := TSQuery("fat & rat")
+			wantQuery := "?::tsquery"
+			wantArgs := []interface{}{"fat & rat"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "expression"
+			f := TSQueryFieldf("phraseto_tsquery(?)", "fat rat")
+			wantQuery := "phraseto_tsquery(?)"
+			wantArgs := []interface{}{"fat rat"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "table qualified"
+			f := NewTSQueryField("query", &TableInfo{Schema: "public", Name: "saved_searches"})
+			wantQuery := "saved_searches.query"
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "excludedTableQualifiers (name)"
+			f := NewTSQueryField("query", &TableInfo{Schema: "public", Name: "saved_searches"})
+			exclude := []string{"saved_searches"}
+			wantQuery := "query"
+			return TT{desc, f, exclude, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "And, Or and Negate"
+			f := TSQuery("fat").And(TSQuery("rat").Or(PlainToTSQuery("", "cat")).Negate())
+			wantQuery := "(?::tsquery && !! (?::tsquery || plainto_tsquery(?)))"
+			wantArgs := []interface{}{"fat", "rat", "cat"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			var _ Field = tt.f
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestTSQueryField_Predicates(t *testing.T) {
+	type TT struct {
+		description string
+		p           Predicate
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	f := NewTSQueryField("query", &TableInfo{Schema: "public", Name: "saved_searches"})
+	tests := []TT{
+		{
+			"IsNull",
+			f.IsNull(),
+			nil,
+			"saved_searches.query IS NULL",
+			nil,
+		},
+		{
+			"IsNotNull",
+			f.IsNotNull(),
+			nil,
+			"saved_searches.query IS NOT NULL",
+			nil,
+		},
+		{
+			"Matches",
+			f.Matches(ToTSVector("", "the text")),
+			nil,
+			"saved_searches.query @@ to_tsvector(?)",
+			[]interface{}{"the text"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.p.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package sq
+
+import "strings"
+
+// TSVectorField either represents a TSVECTOR column or a tsvector expression.
+type TSVectorField struct {
+	// TSVectorField will be one of the following:
+
+	// 1) tsvector expression
+	// Examples of tsvector expressions:
+	// | query                        | args              |
+	// |------------------------------|-------------------|
+	// | to_tsvector(?::regconfig, ?) | english, the text |
+	// | to_tsvector(posts.body)      |                   |
+	// | setweight(to_tsvector(?), ?) | the text, A       |
+	format *string
+	values []interface{}
+
+	// 2) TSVECTOR column
+	// Examples of tsvector columns:
+	// | query               | args |
+	// |---------------------|------|
+	// | posts.search_vector |      |
+	// | search_vector       |      |
+	alias string
+	table Table
+	name  string
+}
+
+// AppendSQLExclude marshals the TSVectorField into a buffer and an args slice.
+// It will not table qualify itself if its table qualifer appears in the
+// excludedTableQualifiers list.
+func (f TSVectorField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	switch {
+	case f.format != nil:
+		// 1) tsvector expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
+	default:
+		// 2) TSVECTOR column
+		tableQualifier := f.table.GetAlias()
+		if tableQualifier == "" {
+			tableQualifier = f.table.GetName()
+		}
+		for _, excludedTableQualifier := range excludedTableQualifiers {
+			if tableQualifier == excludedTableQualifier {
+				tableQualifier = ""
+				break
+			}
+		}
+		if tableQualifier != "" {
+			if strings.ContainsAny(tableQualifier, " \t") {
+				buf.WriteString(`"`)
+				buf.WriteString(tableQualifier)
+				buf.WriteString(`".`)
+			} else {
+				buf.WriteString(tableQualifier)
+				buf.WriteString(".")
+			}
+		}
+		if strings.ContainsAny(f.name, " \t") {
+			buf.WriteString(`"`)
+			buf.WriteString(f.name)
+			buf.WriteString(`"`)
+		} else {
+			buf.WriteString(f.name)
+		}
+	}
+}
+
+// NewTSVectorField returns a new TSVectorField representing a TSVECTOR column.
+func NewTSVectorField(name string, table Table) TSVectorField {
+	return TSVectorField{
+		name:  name,
+		table: table,
+	}
+}
+
+// TSVectorFieldf creates a new tsvector expression.
+func TSVectorFieldf(format string, values ...interface{}) TSVectorField {
+	return TSVectorField{
+		format: &format,
+		values: values,
+	}
+}
+
+// Set returns a FieldAssignment associating the TSVectorField to the value
+// i.e. 'field = value'.
+func (f TSVectorField) Set(value interface{}) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: value,
+	}
+}
+
+// As returns a new TSVectorField with the new field Alias i.e. 'field AS
+// Alias'.
+func (f TSVectorField) As(alias string) TSVectorField {
+	f.alias = alias
+	return f
+}
+
+// IsNull returns an 'X IS NULL' Predicate.
+func (f TSVectorField) IsNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// IsNotNull returns an 'X IS NOT NULL' Predicate.
+func (f TSVectorField) IsNotNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NOT NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// Matches returns an 'X @@ Y' Predicate. It only accepts TSQueryField.
+func (f TSVectorField) Matches(query TSQueryField) Predicate {
+	return CustomPredicate{
+		Format: "? @@ ?",
+		Values: []interface{}{f, query},
+	}
+}
+
+// Concat returns a new TSVectorField concatenating the TSVectorFields i.e.
+// 'X || Y'.
+func (f TSVectorField) Concat(vector TSVectorField) TSVectorField {
+	format := "? || ?"
+	return TSVectorField{
+		format: &format,
+		values: []interface{}{f, vector},
+	}
+}
+
+// SetWeight returns a new TSVectorField with every lexeme labelled with the
+// weight i.e. 'setweight(X, weight)'. The weight must be one of A, B, C or D.
+func (f TSVectorField) SetWeight(weight string) TSVectorField {
+	format := "setweight(?, ?)"
+	return TSVectorField{
+		format: &format,
+		values: []interface{}{f, weight},
+	}
+}
+
+// String implements the fmt.Stringer interface. It returns the string
+// representation of a TSVectorField.
+func (f TSVectorField) String() string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	f.AppendSQLExclude(buf, &args, nil, nil)
+	return questionInterpolate(buf.String(), args...)
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// TSVectorField.
+func (f TSVectorField) GetAlias() string {
+	return f.alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// TSVectorField.
+func (f TSVectorField) GetName() string {
+	return f.name
+}
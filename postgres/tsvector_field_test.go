@@ -0,0 +1,120 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestTSVectorField_AppendSQLExclude(t *testing.T) {
+	type TT struct {
+		description string
+		f           TSVectorField
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			desc := "expression"
+			f := TSVectorFieldf("to_tsvector(?)", "the text")
+			wantQuery := "to_tsvector(?)"
+			wantArgs := []interface{}{"the text"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "table qualified"
+			f := NewTSVectorField("search_vector", &TableInfo{Schema: "public", Name: "posts"})
+			wantQuery := "posts.search_vector"
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "excludedTableQualifiers (alias)"
+			f := NewTSVectorField("search_vector", &TableInfo{Schema: "public", Name: "posts", Alias: "p"})
+			exclude := []string{"p"}
+			wantQuery := "search_vector"
+			return TT{desc, f, exclude, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "quoted whitespace"
+			f := NewTSVectorField("search vector", &TableInfo{Schema: "public", Name: "blog posts"})
+			wantQuery := `"blog posts"."search vector"`
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "Concat and SetWeight"
+			p := NewTSVectorField("search_vector", &TableInfo{Schema: "public", Name: "posts"})
+			f := p.SetWeight("A").Concat(ToTSVector("", "extra"))
+			wantQuery := "setweight(posts.search_vector, ?) || to_tsvector(?)"
+			wantArgs := []interface{}{"A", "extra"}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			var _ Field = tt.f
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestTSVectorField_Predicates(t *testing.T) {
+	type TT struct {
+		description string
+		p           Predicate
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	f := NewTSVectorField("search_vector", &TableInfo{Schema: "public", Name: "posts"})
+	tests := []TT{
+		{
+			"IsNull",
+			f.IsNull(),
+			nil,
+			"posts.search_vector IS NULL",
+			nil,
+		},
+		{
+			"IsNotNull",
+			f.IsNotNull(),
+			nil,
+			"posts.search_vector IS NOT NULL",
+			nil,
+		},
+		{
+			"Matches",
+			f.Matches(ToTSQuery("english", "fat & rat")),
+			nil,
+			"posts.search_vector @@ to_tsquery(?::regconfig, ?)",
+			[]interface{}{"english", "fat & rat"},
+		},
+		{
+			"Matches Not",
+			f.Matches(TSQuery("fat")).Not(),
+			nil,
+			"NOT posts.search_vector @@ ?::tsquery",
+			[]interface{}{"fat"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.p.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
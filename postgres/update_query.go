@@ -15,6 +15,7 @@ import (
 // UpdateQuery represents an UPDATE query.
 type UpdateQuery struct {
 	nested bool
+	format sqlFormat
 	// WITH
 	CTEs []CTE
 	// UPDATE
@@ -33,6 +34,9 @@ type UpdateQuery struct {
 	ColumnMapper func(*Column)
 	RowMapper    func(*Row)
 	Accumulator  func()
+	// AutoValidate makes Fetch and Exec call Validate before running the
+	// query.
+	AutoValidate bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -64,7 +68,7 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// WITH
 	if !q.nested {
-		appendCTEs(buf, args, q.CTEs, q.FromTable, q.JoinTables)
+		appendCTEs(buf, args, q.CTEs, q.FromTable, q.JoinTables, q.format)
 	}
 	// UPDATE
 	buf.WriteString("UPDATE ")
@@ -84,16 +88,18 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// SET
 	if len(q.Assignments) > 0 {
-		buf.WriteString(" SET ")
+		q.format.newline(buf)
+		buf.WriteString("SET ")
 		q.Assignments.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
 	}
 	// FROM
 	if q.FromTable != nil {
-		buf.WriteString(" FROM ")
+		q.format.newline(buf)
+		buf.WriteString("FROM ")
 		switch v := q.FromTable.(type) {
 		case Query:
 			buf.WriteString("(")
-			v.NestThis().AppendSQL(buf, args, nil)
+			q.format.appendQuery(buf, args, v)
 			buf.WriteString(")")
 		default:
 			q.FromTable.AppendSQL(buf, args, nil)
@@ -106,18 +112,19 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	}
 	// JOIN
 	if len(q.JoinTables) > 0 {
-		buf.WriteString(" ")
-		q.JoinTables.AppendSQL(buf, args, nil)
+		q.format.appendJoinTables(buf, args, q.JoinTables)
 	}
 	// WHERE
 	if len(q.WherePredicate.Predicates) > 0 {
-		buf.WriteString(" WHERE ")
+		q.format.newline(buf)
+		buf.WriteString("WHERE ")
 		q.WherePredicate.toplevel = true
-		q.WherePredicate.AppendSQLExclude(buf, args, nil, nil)
+		q.format.appendPredicate(buf, args, q.WherePredicate, nil)
 	}
 	// RETURNING
 	if len(q.ReturningFields) > 0 {
-		buf.WriteString(" RETURNING ")
+		q.format.newline(buf)
+		buf.WriteString("RETURNING ")
 		q.ReturningFields.AppendSQLExcludeWithAlias(buf, args, nil, nil)
 	}
 	if !q.nested {
@@ -125,15 +132,21 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 		buf.Reset()
 		questionToDollarPlaceholders(buf, query)
 		if q.Log != nil {
+			logQuery, logArgs := buf.String(), *args
+			interpolate := questionInterpolate
+			if Lpretty&q.LogFlag != 0 && !q.format.pretty {
+				logQuery, logArgs = prettyLogQuery(q)
+				query, interpolate = logQuery, dollarInterpolate
+			}
 			var logOutput string
 			switch {
 			case Lstats&q.LogFlag != 0:
-				logOutput = "\n----[ Executing query ]----\n" + buf.String() + " " + fmt.Sprint(*args) +
-					"\n----[ with bind values ]----\n" + questionInterpolate(query, *args...)
+				logOutput = "\n----[ Executing query ]----\n" + logQuery + " " + fmt.Sprint(logArgs) +
+					"\n----[ with bind values ]----\n" + interpolate(query, logArgs...)
 			case Linterpolate&q.LogFlag != 0:
-				logOutput = questionInterpolate(query, *args...)
+				logOutput = interpolate(query, logArgs...)
 			default:
-				logOutput = buf.String() + " " + fmt.Sprint(*args)
+				logOutput = logQuery + " " + fmt.Sprint(logArgs)
 			}
 			switch q.Log.(type) {
 			case *log.Logger:
@@ -319,6 +332,7 @@ func (q UpdateQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, int64(rowcount), elapsed, err)
 		if Lresults&q.LogFlag != 0 && rowcount > 5 {
 			logBuf.WriteString("\n...")
 		}
@@ -341,10 +355,16 @@ func (q UpdateQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.ReturningFields = r.fields
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return err
+		}
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
 	} else {
@@ -419,6 +439,11 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 		db = q.DB
 	}
+	if q.AutoValidate {
+		if err = q.Validate(); err != nil {
+			return rowsAffected, err
+		}
+	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
 	defer func() {
@@ -435,6 +460,7 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			return
 		}
 		elapsed := time.Since(start)
+		logQueryStats(q.Log, q, rowsAffected, elapsed, err)
 		if Lstats&q.LogFlag != 0 && ErowsAffected&flag != 0 {
 			logBuf.WriteString("\n(Updated ")
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
@@ -456,6 +482,7 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	ctx = fingerprintContext(ctx, db, q)
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
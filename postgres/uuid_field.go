@@ -0,0 +1,350 @@
+package sq
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UUIDField either represents a UUID column, a uuid expression or a literal
+// uuid value.
+type UUIDField struct {
+	// UUIDField will be one of the following:
+
+	// 1) uuid expression
+	// Examples of uuid expressions:
+	// | query             | args |
+	// |-------------------|------|
+	// | gen_random_uuid() |      |
+	format *string
+	values []interface{}
+
+	// 2) Literal uuid value
+	// Examples of literal uuid values:
+	// | query   | args                                 |
+	// |---------|--------------------------------------|
+	// | ?::uuid | 5e0e1d3a-8e2b-4ac4-9f3e-2b1c4d6f7a80 |
+	value *[16]byte
+
+	// 3) UUID column
+	// Examples of uuid columns:
+	// | query      | args |
+	// |------------|------|
+	// | media.uuid |      |
+	// | uuid       |      |
+	alias      string
+	table      Table
+	name       string
+	descending *bool
+}
+
+// AppendSQLExclude marshals the UUIDField into an SQL query and args as
+// described in the UUIDField internal struct comments.
+func (f UUIDField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	switch {
+	case f.format != nil:
+		// 1) uuid expression
+		expandValues(buf, args, excludedTableQualifiers, *f.format, f.values)
+	case f.value != nil:
+		// 2) Literal uuid value
+		buf.WriteString("?::uuid")
+		*args = append(*args, formatUUID(*f.value))
+	default:
+		// 3) UUID column
+		tableQualifier := f.table.GetAlias()
+		if tableQualifier == "" {
+			tableQualifier = f.table.GetName()
+		}
+		for _, excludedTableQualifier := range excludedTableQualifiers {
+			if tableQualifier == excludedTableQualifier {
+				tableQualifier = ""
+				break
+			}
+		}
+		if tableQualifier != "" {
+			if strings.ContainsAny(tableQualifier, " \t") {
+				buf.WriteString(`"`)
+				buf.WriteString(tableQualifier)
+				buf.WriteString(`".`)
+			} else {
+				buf.WriteString(tableQualifier)
+				buf.WriteString(".")
+			}
+		}
+		if strings.ContainsAny(f.name, " \t") {
+			buf.WriteString(`"`)
+			buf.WriteString(f.name)
+			buf.WriteString(`"`)
+		} else {
+			buf.WriteString(f.name)
+		}
+	}
+	if f.descending != nil {
+		if *f.descending {
+			buf.WriteString(" DESC")
+		} else {
+			buf.WriteString(" ASC")
+		}
+	}
+}
+
+// NewUUIDField returns a new UUIDField representing a UUID column.
+func NewUUIDField(name string, table Table) UUIDField {
+	return UUIDField{
+		name:  name,
+		table: table,
+	}
+}
+
+// UUID returns a new UUIDField representing a literal uuid value.
+func UUID(value [16]byte) UUIDField {
+	return UUIDField{
+		value: &value,
+	}
+}
+
+// GenRandomUUID represents the gen_random_uuid() function.
+func GenRandomUUID() UUIDField {
+	format := "gen_random_uuid()"
+	return UUIDField{
+		format: &format,
+	}
+}
+
+// Set returns a FieldAssignment associating the UUIDField to the value i.e.
+// 'field = value'. Values convertible to [16]byte (such as uuid.UUID from
+// github.com/google/uuid) are sent as uuids.
+func (f UUIDField) Set(value interface{}) FieldAssignment {
+	return FieldAssignment{
+		Field: f,
+		Value: uuidValue(value),
+	}
+}
+
+// As returns a new UUIDField with the new field Alias i.e. 'field AS Alias'.
+func (f UUIDField) As(alias string) UUIDField {
+	f.alias = alias
+	return f
+}
+
+// Asc returns a new UUIDField indicating that it should be ordered in
+// ascending order i.e. 'ORDER BY field ASC'.
+func (f UUIDField) Asc() UUIDField {
+	desc := false
+	f.descending = &desc
+	return f
+}
+
+// Desc returns a new UUIDField indicating that it should be ordered in
+// descending order i.e. 'ORDER BY field DESC'.
+func (f UUIDField) Desc() UUIDField {
+	desc := true
+	f.descending = &desc
+	return f
+}
+
+// IsNull returns an 'X IS NULL' Predicate.
+func (f UUIDField) IsNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// IsNotNull returns an 'X IS NOT NULL' Predicate.
+func (f UUIDField) IsNotNull() Predicate {
+	return CustomPredicate{
+		Format: "? IS NOT NULL",
+		Values: []interface{}{f},
+	}
+}
+
+// Eq returns an 'X = Y' Predicate. It accepts a UUIDField, a value
+// convertible to [16]byte or a uuid string.
+func (f UUIDField) Eq(value interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? = ?",
+		Values: []interface{}{f, uuidValue(value)},
+	}
+}
+
+// Ne returns an 'X <> Y' Predicate. It accepts a UUIDField, a value
+// convertible to [16]byte or a uuid string.
+func (f UUIDField) Ne(value interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <> ?",
+		Values: []interface{}{f, uuidValue(value)},
+	}
+}
+
+// In returns an 'X IN (Y)' Predicate. Each element of a slice is converted
+// the same way as in Eq. If Y is an ArrayParameter or a slice of more than
+// 1000 elements, it is bound as a single array i.e. 'X = ANY($1)'.
+func (f UUIDField) In(v interface{}) Predicate {
+	if p, ok := arrayParam(v); ok {
+		return CustomPredicate{
+			Format: "? = ANY(?)",
+			Values: []interface{}{f, p},
+		}
+	}
+	var format string
+	var values []interface{}
+	switch v := v.(type) {
+	case RowValue:
+		format = "? IN ?"
+		values = []interface{}{f, v}
+	case Query:
+		format = "? IN (?)"
+		values = []interface{}{f, v.NestThis()}
+	default:
+		elems := uuidValues(v)
+		if len(elems) == 0 {
+			format = "? IN (NULL)"
+			values = []interface{}{f}
+			break
+		}
+		format = "? IN (?)"
+		values = []interface{}{f, uuidList(elems)}
+	}
+	return CustomPredicate{
+		Format: format,
+		Values: values,
+	}
+}
+
+// NotIn returns an 'X NOT IN (Y)' Predicate. Each element of a slice is
+// converted the same way as in Eq. If Y is an ArrayParameter or a slice of more
+// than 1000 elements, it is bound as a single array i.e. 'X <> ALL($1)'.
+func (f UUIDField) NotIn(v interface{}) Predicate {
+	if p, ok := arrayParam(v); ok {
+		return CustomPredicate{
+			Format: "? <> ALL(?)",
+			Values: []interface{}{f, p},
+		}
+	}
+	var format string
+	var values []interface{}
+	switch v := v.(type) {
+	case RowValue:
+		format = "? NOT IN ?"
+		values = []interface{}{f, v}
+	case Query:
+		format = "? NOT IN (?)"
+		values = []interface{}{f, v.NestThis()}
+	default:
+		elems := uuidValues(v)
+		if len(elems) == 0 {
+			format = "? NOT IN (NULL)"
+			values = []interface{}{f}
+			break
+		}
+		format = "? NOT IN (?)"
+		values = []interface{}{f, uuidList(elems)}
+	}
+	return CustomPredicate{
+		Format: format,
+		Values: values,
+	}
+}
+
+// String implements the fmt.Stringer interface. It returns the string
+// representation of a UUIDField.
+func (f UUIDField) String() string {
+	buf := &strings.Builder{}
+	var args []interface{}
+	f.AppendSQLExclude(buf, &args, nil, nil)
+	return questionInterpolate(buf.String(), args...)
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// UUIDField.
+func (f UUIDField) GetAlias() string {
+	return f.alias
+}
+
+// GetName implements the Field interface. It returns the Name of the
+// UUIDField.
+func (f UUIDField) GetName() string {
+	return f.name
+}
+
+var uuidType = reflect.TypeOf([16]byte{})
+
+// uuidValue converts a value convertible to [16]byte into a literal UUIDField.
+// Any other value is returned as is.
+func uuidValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if v := reflect.ValueOf(value); v.Type().ConvertibleTo(uuidType) && v.Kind() == reflect.Array {
+		return UUID(v.Convert(uuidType).Interface().([16]byte))
+	}
+	return value
+}
+
+// uuidValues converts every element of a slice with uuidValue. Any other value
+// is treated as a slice of one element.
+func uuidValues(value interface{}) []interface{} {
+	if value == nil {
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		return []interface{}{uuidValue(value)}
+	}
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = uuidValue(v.Index(i).Interface())
+	}
+	return values
+}
+
+// uuidList is a list of values converted with uuidValue. It is kept as a single
+// value of an IN predicate so that the list can be collapsed by Fingerprint.
+type uuidList []interface{}
+
+// AppendSQLExclude marshals the uuidList into a comma separated list of
+// values.
+func (l uuidList) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	for i, value := range l {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		appendSQLValue(buf, args, excludedTableQualifiers, value)
+	}
+}
+
+// formatUUID formats a uuid in its canonical 8-4-4-4-12 form.
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// parseUUID parses a uuid in its canonical form (with or without dashes and
+// braces) or as its raw 16 bytes.
+func parseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+	if len(s) == 16 {
+		copy(u[:], s)
+		return u, nil
+	}
+	text := strings.ReplaceAll(strings.Trim(s, "{}"), "-", "")
+	if len(text) != 32 {
+		return u, fmt.Errorf("%q is not a valid uuid", s)
+	}
+	_, err := hex.Decode(u[:], []byte(text))
+	if err != nil {
+		return u, fmt.Errorf("%q is not a valid uuid: %w", s, err)
+	}
+	return u, nil
+}
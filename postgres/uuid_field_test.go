@@ -0,0 +1,162 @@
+package sq
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type testUUID [16]byte
+
+var testUUIDValue = [16]byte{0x5e, 0x0e, 0x1d, 0x3a, 0x8e, 0x2b, 0x4a, 0xc4, 0x9f, 0x3e, 0x2b, 0x1c, 0x4d, 0x6f, 0x7a, 0x80}
+
+const testUUIDString = "5e0e1d3a-8e2b-4ac4-9f3e-2b1c4d6f7a80"
+
+func TestUUIDField_AppendSQLExclude(t *testing.T) {
+	type TT struct {
+		description string
+		f           UUIDField
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			desc := "literal value"
+			f := UUID(testUUIDValue)
+			wantQuery := "?::uuid"
+			wantArgs := []interface{}{testUUIDString}
+			return TT{desc, f, nil, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			desc := "GenRandomUUID"
+			f := GenRandomUUID()
+			wantQuery := "gen_random_uuid()"
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "table qualified"
+			f := NewUUIDField("uuid", &TableInfo{Schema: "public", Name: "media"})
+			wantQuery := "media.uuid"
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "excludedTableQualifiers (alias)"
+			f := NewUUIDField("uuid", &TableInfo{Schema: "public", Name: "media", Alias: "m"})
+			exclude := []string{"m"}
+			wantQuery := "uuid"
+			return TT{desc, f, exclude, wantQuery, nil}
+		}(),
+		func() TT {
+			desc := "quoted whitespace Desc"
+			f := NewUUIDField("media uuid", &TableInfo{Schema: "public", Name: "all media"}).Desc()
+			wantQuery := `"all media"."media uuid" DESC`
+			return TT{desc, f, nil, wantQuery, nil}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			var _ Field = tt.f
+			tt.f.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUUIDField_Predicates(t *testing.T) {
+	type TT struct {
+		description string
+		p           Predicate
+		exclude     []string
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	m := MEDIA().As("m")
+	tests := []TT{
+		{"IsNull", m.UUID.IsNull(), nil, "m.uuid IS NULL", nil},
+		{"IsNotNull", m.UUID.IsNotNull(), nil, "m.uuid IS NOT NULL", nil},
+		{"Eq field", m.UUID.Eq(m.UUID), []string{"m"}, "uuid = uuid", nil},
+		{"Eq [16]byte", m.UUID.Eq(testUUIDValue), nil, "m.uuid = ?::uuid", []interface{}{testUUIDString}},
+		{"Eq named [16]byte type", m.UUID.Eq(testUUID(testUUIDValue)), nil, "m.uuid = ?::uuid", []interface{}{testUUIDString}},
+		{"Eq string", m.UUID.Eq(testUUIDString), nil, "m.uuid = ?", []interface{}{testUUIDString}},
+		{"Ne [16]byte", m.UUID.Ne(testUUIDValue), nil, "m.uuid <> ?::uuid", []interface{}{testUUIDString}},
+		{
+			"In slice",
+			m.UUID.In([]testUUID{testUUIDValue, {}}),
+			nil,
+			"m.uuid IN (?::uuid, ?::uuid)",
+			[]interface{}{testUUIDString, "00000000-0000-0000-0000-000000000000"},
+		},
+		{"In empty slice", m.UUID.In([][16]byte{}), nil, "m.uuid IN (NULL)", nil},
+		{
+			"In subquery",
+			m.UUID.In(Select(m.UUID).From(m)),
+			nil,
+			"m.uuid IN (SELECT m.uuid FROM public.media AS m)",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var args []interface{}
+			tt.p.AppendSQLExclude(buf, &args, nil, tt.exclude)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUUIDField_Set(t *testing.T) {
+	is := is.New(t)
+	m := MEDIA()
+	gotQuery, gotArgs := InsertInto(m).Valuesx(func(col *Column) {
+		col.Set(m.UUID, testUUID(testUUIDValue))
+		col.SetString(m.NAME, "a.png")
+	}).ToSQL()
+	is.Equal("INSERT INTO public.media (uuid, name) VALUES ($1::uuid, $2)", gotQuery)
+	is.Equal([]interface{}{testUUIDString, "a.png"}, gotArgs)
+
+	gotQuery, gotArgs = Update(m).Set(m.UUID.Set(GenRandomUUID())).Where(m.UUID.Eq(testUUIDValue)).ToSQL()
+	is.Equal("UPDATE public.media SET uuid = gen_random_uuid() WHERE media.uuid = $1::uuid", gotQuery)
+	is.Equal([]interface{}{testUUIDString}, gotArgs)
+}
+
+func TestRow_UUID(t *testing.T) {
+	is := is.New(t)
+	m := MEDIA()
+	mapper := func(row *Row) ([16]byte, bool, [16]byte, bool) {
+		return row.UUID(m.UUID), row.UUIDValid(m.UUID), row.UUID(m.UUID), row.UUIDValid(m.UUID)
+	}
+	r := &Row{}
+	mapper(r)
+	is.Equal(4, len(r.fields))
+	// Populate the destinations as if they had been scanned.
+	r.jsonValues = make([]json.RawMessage, len(r.fields))
+	*r.dest[0].(*sql.NullString) = sql.NullString{String: testUUIDString, Valid: true}
+	*r.dest[1].(*sql.NullString) = sql.NullString{String: testUUIDString, Valid: true}
+	u, valid, null, nullValid := mapper(r)
+	is.Equal(testUUIDValue, u)
+	is.True(valid)
+	is.Equal([16]byte{}, null)
+	is.True(!nullValid)
+
+	_, err := parseUUID("not-a-uuid")
+	is.True(err != nil)
+	parsed, err := parseUUID("{5E0E1D3A8E2B4AC49F3E2B1C4D6F7A80}")
+	is.NoErr(err)
+	is.Equal(testUUIDValue, parsed)
+	is.Equal(testUUIDString, formatUUID(testUUIDValue))
+}
@@ -0,0 +1,281 @@
+package sq
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ValidationError is returned by Validate when a query refers to tables that
+// it cannot see. Each problem quotes the SQL of the offending field.
+type ValidationError struct {
+	Problems []string
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	return "sq: invalid query: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks that every column in the SelectQuery belongs to a table in
+// its FROM or JOIN clauses (or those of an enclosing query, for correlated
+// subqueries), that no table alias is used twice and that no unqualified
+// column is ambiguous. CTEs and subqueries are checked as well. It returns a
+// ValidationError listing every problem found, or nil.
+func (q SelectQuery) Validate() error {
+	return validate(q)
+}
+
+// Validate checks the InsertQuery the same way as SelectQuery.Validate.
+func (q InsertQuery) Validate() error {
+	return validate(q)
+}
+
+// Validate checks the UpdateQuery the same way as SelectQuery.Validate.
+func (q UpdateQuery) Validate() error {
+	return validate(q)
+}
+
+// Validate checks the DeleteQuery the same way as SelectQuery.Validate.
+func (q DeleteQuery) Validate() error {
+	return validate(q)
+}
+
+// Validate checks every query in the VariadicQuery the same way as
+// SelectQuery.Validate.
+func (vq VariadicQuery) Validate() error {
+	return validate(vq)
+}
+
+func validate(q Query) error {
+	v := &validator{seenCTEs: map[string]bool{}}
+	v.validateQuery(q, nil)
+	if len(v.problems) > 0 {
+		return ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+type validator struct {
+	problems []string
+	seenCTEs map[string]bool
+}
+
+// validateQuery validates a query whose enclosing queries have the outer
+// tables in scope.
+func (v *validator) validateQuery(query Query, outer []Table) {
+	var ctes []CTE
+	var tables []Table
+	var nodes []interface{}
+	switch q := query.(type) {
+	case SelectQuery:
+		ctes = q.CTEs
+		tables = append(tables, q.FromTable)
+		for _, joinTable := range q.JoinTables {
+			tables = append(tables, joinTable.Table)
+			nodes = append(nodes, joinTable.OnPredicates)
+		}
+		nodes = append(nodes, q.SelectFields, q.DistinctOn, q.WherePredicate, q.GroupByFields,
+			q.HavingPredicate, q.Windows, q.OrderByFields)
+	case InsertQuery:
+		ctes = q.CTEs
+		tables = append(tables, q.IntoTable)
+		nodes = append(nodes, q.InsertColumns, q.RowValues, q.SelectQuery, q.ConflictFields,
+			q.ConflictPredicate, q.Resolution, q.ResolutionPredicate, q.ReturningFields)
+	case UpdateQuery:
+		ctes = q.CTEs
+		tables = append(tables, q.UpdateTable, q.FromTable)
+		for _, joinTable := range q.JoinTables {
+			tables = append(tables, joinTable.Table)
+			nodes = append(nodes, joinTable.OnPredicates)
+		}
+		nodes = append(nodes, q.Assignments, q.WherePredicate, q.ReturningFields)
+	case DeleteQuery:
+		ctes = q.CTEs
+		tables = append(tables, q.FromTable, q.UsingTable)
+		for _, joinTable := range q.JoinTables {
+			tables = append(tables, joinTable.Table)
+			nodes = append(nodes, joinTable.OnPredicates)
+		}
+		nodes = append(nodes, q.WherePredicate, q.ReturningFields)
+	case VariadicQuery:
+		// The ORDER BY of a VariadicQuery refers to the output columns, so
+		// only the member queries are checked.
+		for _, member := range q.Queries {
+			v.validateQuery(member, outer)
+		}
+		return
+	case ValuesQuery:
+		nodes = append(nodes, q.RowValues)
+	case Subquery:
+		v.validateQuery(q.GetQuery(), outer)
+		return
+	default:
+		return
+	}
+	for _, cte := range ctes {
+		v.validateCTE(cte)
+	}
+	// Tables in scope
+	var scope []Table
+	qualifiers := map[string]bool{}
+	for _, table := range tables {
+		if table == nil || reflect.ValueOf(table).Kind() == reflect.Ptr && reflect.ValueOf(table).IsNil() {
+			continue
+		}
+		switch table := table.(type) {
+		case CTE:
+			v.validateCTE(table)
+		case Subquery:
+			v.validateQuery(table.GetQuery(), outer)
+		}
+		qualifier := tableQualifier(table)
+		if qualifiers[qualifier] {
+			v.problems = append(v.problems, "table alias "+qualifier+" is used more than once")
+		}
+		qualifiers[qualifier] = true
+		scope = append(scope, table)
+	}
+	inner := append(scope, outer...)
+	for _, node := range nodes {
+		Walk(node, func(node interface{}) bool {
+			if q, ok := node.(Query); ok {
+				v.validateQuery(q, inner)
+				return false
+			}
+			if field, ok := node.(Field); ok {
+				v.validateField(field, inner)
+			}
+			return true
+		})
+	}
+}
+
+// validateCTE validates the CTE's query, once per CTE name.
+func (v *validator) validateCTE(cte CTE) {
+	name := cte.GetName()
+	if v.seenCTEs[name] {
+		return
+	}
+	v.seenCTEs[name] = true
+	if q := cte.GetQuery(); q != nil {
+		v.validateQuery(q, nil)
+	}
+}
+
+// validateField checks that a column field belongs to a table in scope, or if
+// the column is unqualified that it does not exist in more than one table in
+// scope.
+func (v *validator) validateField(field Field, scope []Table) {
+	table, name, ok := fieldColumn(field)
+	if !ok {
+		return
+	}
+	if table == nil {
+		var matches []string
+		for _, t := range scope {
+			for _, column := range tableColumns(t) {
+				if column == name {
+					matches = append(matches, tableQualifier(t))
+					break
+				}
+			}
+		}
+		if len(matches) > 1 {
+			v.problems = append(v.problems, "column "+name+" is ambiguous, it exists in "+strings.Join(matches, ", "))
+		}
+		return
+	}
+	qualifier := tableQualifier(table)
+	for _, t := range scope {
+		if tableQualifier(t) == qualifier {
+			return
+		}
+	}
+	buf := &strings.Builder{}
+	var args []interface{}
+	field.AppendSQLExclude(buf, &args, nil, nil)
+	v.problems = append(v.problems, buf.String()+" refers to table "+qualifier+" which is not in the FROM or JOIN clauses")
+}
+
+// tableQualifier returns the name that a table's columns are qualified with.
+func tableQualifier(table Table) string {
+	if alias := table.GetAlias(); alias != "" {
+		return alias
+	}
+	return table.GetName()
+}
+
+// fieldColumn returns the table and name of a field if it is a column.
+func fieldColumn(field Field) (table Table, name string, ok bool) {
+	switch f := field.(type) {
+	case ArrayField:
+		return f.table, f.name, f.format == nil && f.value == nil && f.name != ""
+	case BinaryField:
+		return f.table, f.name, f.value == nil && f.name != ""
+	case BooleanField:
+		return f.table, f.name, f.format == nil && f.value == nil && f.name != ""
+	case JSONField:
+		return f.table, f.name, f.value == nil && f.name != ""
+	case NumberField:
+		return f.table, f.name, f.format == nil && f.value == nil && f.name != ""
+	case StringField:
+		return f.table, f.name, f.format == nil && f.value == nil && f.name != ""
+	case TimeField:
+		return f.table, f.name, f.format == nil && f.value == nil && f.name != ""
+	case UUIDField:
+		return f.table, f.name, f.format == nil && f.value == nil && f.name != ""
+	case RangeField:
+		return f.table, f.name, f.format == nil && f.name != ""
+	case TSQueryField:
+		return f.table, f.name, f.format == nil && f.value == nil && f.name != ""
+	case TSVectorField:
+		return f.table, f.name, f.format == nil && f.name != ""
+	}
+	return nil, "", false
+}
+
+// tableColumns returns the column names of a table: the keys of a CTE or
+// Subquery, or the names of the fields of a generated table struct.
+func tableColumns(table Table) []string {
+	var columns []string
+	switch t := table.(type) {
+	case CTE:
+		for column := range t {
+			switch column {
+			case metadataQuery, metadataRecursive, metadataName, metadataAlias, metadataColumns,
+				metadataMaterial, metadataSearch, metadataCycle:
+				continue
+			}
+			columns = append(columns, column)
+		}
+		return columns
+	case Subquery:
+		for column := range t {
+			switch column {
+			case metadataQuery, metadataAlias:
+				continue
+			}
+			columns = append(columns, column)
+		}
+		return columns
+	}
+	value := reflect.ValueOf(table)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).PkgPath != "" {
+			continue // unexported
+		}
+		if field, ok := value.Field(i).Interface().(Field); ok {
+			columns = append(columns, field.GetName())
+		}
+	}
+	return columns
+}
@@ -0,0 +1,167 @@
+package sq
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestValidate(t *testing.T) {
+	type TT struct {
+		description  string
+		validate     func() error
+		wantProblems []string
+	}
+	u, ur, s := USERS().As("u"), USER_ROLES().As("ur"), SESSIONS().As("s")
+	tests := []TT{
+		{
+			"valid select",
+			From(u).
+				Join(ur, ur.USER_ID.Eq(u.USER_ID)).
+				Where(Exists(From(s).Where(s.USER_ID.Eq(u.USER_ID)).SelectOne())).
+				OrderBy(ur.ROLE).
+				Select(u.USER_ID, u.EMAIL.Lower()).
+				Validate,
+			nil,
+		},
+		{
+			"table not in FROM",
+			From(s).Select(u.DISPLAYNAME, s.HASH).Validate,
+			[]string{"u.displayname refers to table u which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"table not in FROM inside an expression",
+			From(s).Where(u.EMAIL.Lower().EqString("x")).Select(s.HASH).Validate,
+			[]string{"u.email refers to table u which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"table alias differs",
+			From(USERS()).Select(u.USER_ID).Validate,
+			[]string{"u.user_id refers to table u which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"duplicate alias",
+			From(u).Join(USERS().As("u"), Bool(true)).Select(u.USER_ID).Validate,
+			[]string{"table alias u is used more than once"},
+		},
+		{
+			"ambiguous unqualified column",
+			From(u).Join(ur, ur.USER_ID.Eq(u.USER_ID)).Select(NewNumberField("user_id", nil)).Validate,
+			[]string{"column user_id is ambiguous, it exists in u, ur"},
+		},
+		{
+			"unambiguous unqualified column",
+			From(u).Join(ur, ur.USER_ID.Eq(u.USER_ID)).Select(NewStringField("email", nil)).Validate,
+			nil,
+		},
+		{
+			"subquery refers to table outside of scope",
+			From(u).
+				Where(Exists(From(s).Where(s.USER_ID.Eq(ur.USER_ID)).SelectOne())).
+				Select(u.USER_ID).
+				Validate,
+			[]string{"ur.user_id refers to table ur which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"derived table cannot see sibling tables",
+			func() error {
+				subq := From(s).Where(s.USER_ID.Eq(u.USER_ID)).Select(s.USER_ID).Subquery("subq")
+				return From(u).Join(subq, subq.NumberField("user_id").Eq(u.USER_ID)).Select(u.USER_ID).Validate()
+			},
+			[]string{"u.user_id refers to table u which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"CTE",
+			func() error {
+				cte := From(ur).Where(ur.ROLE.EqString("admin")).Select(ur.USER_ID, u.EMAIL).CTE("admins")
+				return From(cte).Select(cte.NumberField("user_id")).Validate()
+			},
+			[]string{"u.email refers to table u which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"CTE not in FROM",
+			func() error {
+				cte := From(ur).Select(ur.USER_ID).CTE("admins")
+				return From(u).Select(cte.NumberField("user_id")).Validate()
+			},
+			[]string{"admins.user_id refers to table admins which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"recursive CTE",
+			func() error {
+				tens := RecursiveCTE("tens", "n")
+				n := tens.NumberField("n")
+				tens = tens.
+					Initial(Select(Int(10))).
+					UnionAll(Select(n.Add(10)).From(tens).Where(n.LtInt(100)))
+				return From(tens).Select(n).Validate()
+			},
+			nil,
+		},
+		{
+			"insert",
+			InsertInto(u).
+				Columns(u.USER_ID, ur.ROLE).
+				Values(1, "admin").
+				Returning(u.USER_ID).
+				Validate,
+			[]string{"ur.role refers to table ur which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"update",
+			Update(u).
+				Set(u.EMAIL.Set(s.HASH)).
+				From(ur).
+				Where(ur.USER_ID.Eq(u.USER_ID)).
+				Validate,
+			[]string{"s.hash refers to table s which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"delete",
+			DeleteFrom(u).
+				Using(ur).
+				Where(ur.USER_ID.Eq(u.USER_ID), s.HASH.IsNull()).
+				Validate,
+			[]string{"s.hash refers to table s which is not in the FROM or JOIN clauses"},
+		},
+		{
+			"union",
+			Union(
+				From(u).Select(u.USER_ID),
+				From(ur).Select(u.USER_ID),
+			).Validate,
+			[]string{"u.user_id refers to table u which is not in the FROM or JOIN clauses"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			err := tt.validate()
+			if tt.wantProblems == nil {
+				is.NoErr(err)
+				return
+			}
+			var validationErr ValidationError
+			is.True(errors.As(err, &validationErr))
+			is.Equal(tt.wantProblems, validationErr.Problems)
+		})
+	}
+}
+
+func TestValidate_AutoValidate(t *testing.T) {
+	is := is.New(t)
+	u, s := USERS().As("u"), SESSIONS().As("s")
+	// The query is never run, so a nil *sql.DB is enough.
+	var db *sql.DB
+	base := WithValidation()
+	err := base.From(s).Selectx(func(row *Row) { row.String(u.DISPLAYNAME) }, nil).Fetch(db)
+	is.Equal("sq: invalid query: u.displayname refers to table u which is not in the FROM or JOIN clauses", err.Error())
+	_, err = base.DeleteFrom(u).Where(s.HASH.IsNull()).Exec(db, 0)
+	is.Equal("sq: invalid query: s.hash refers to table s which is not in the FROM or JOIN clauses", err.Error())
+	_, err = base.Update(u).Set(u.EMAIL.Set(s.HASH)).Exec(db, 0)
+	is.Equal("sq: invalid query: s.hash refers to table s which is not in the FROM or JOIN clauses", err.Error())
+}
@@ -1,6 +1,7 @@
 package sq
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/matryer/is"
@@ -92,6 +93,18 @@ func TestVariadicQueries_AppendSQL(t *testing.T) {
 			"SELECT $1 UNION SELECT $2 UNION SELECT $3",
 			[]interface{}{1, 2, 3},
 		},
+		{
+			"ORDER BY, LIMIT and OFFSET",
+			Union(Select(Int(1).As("n")), q2).OrderBy(FieldLiteral("n")).Limit(10).Offset(5),
+			"SELECT $1 AS n UNION SELECT $2 ORDER BY n LIMIT $3 OFFSET $4",
+			[]interface{}{1, 2, int64(10), int64(5)},
+		},
+		{
+			"nested variadic query with ORDER BY and LIMIT",
+			UnionAll(q1, Union(q2, q3).OrderBy(FieldLiteral("1")).Limit(1)),
+			"SELECT $1 UNION ALL (SELECT $2 UNION SELECT $3 ORDER BY 1 LIMIT $4)",
+			[]interface{}{1, 2, 3, int64(1)},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -129,3 +142,47 @@ func TestVariadicQueries_Basic(t *testing.T) {
 	is.Equal(true, vq.topLevel)
 	is.Equal(QueryExceptAll, vq.Operator)
 }
+
+func TestVariadicQueries_OrderBy(t *testing.T) {
+	is := is.New(t)
+	u, ur := USERS().As("u"), USER_ROLES().As("ur")
+	vq := Union(
+		From(u).Select(u.USER_ID, u.DISPLAYNAME.As("name")),
+		From(u).Join(ur, ur.USER_ID.Eq(u.USER_ID)).Select(ur.USER_ID, ur.ROLE),
+	).OrderBy(u.DISPLAYNAME.As("name").Desc(), u.USER_ID, ur.ROLE).Limit(5)
+	gotQuery, gotArgs := vq.ToSQL()
+	is.Equal("SELECT u.user_id, u.displayname AS name FROM public.users AS u"+
+		" UNION SELECT ur.user_id, ur.role FROM public.users AS u JOIN public.user_roles AS ur ON ur.user_id = u.user_id"+
+		" ORDER BY name DESC, user_id, role LIMIT $1", gotQuery)
+	is.Equal([]interface{}{int64(5)}, gotArgs)
+}
+
+func TestVariadicQueries_Fetch(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "VariadicQueries_Fetch")
+	is.NoErr(err)
+	defer db.Close()
+	u := USERS().As("u")
+	var userID int
+	var userIDs []int
+	err = Union(
+		From(u).Where(u.USER_ID.LtInt(3)),
+		From(u).Where(u.USER_ID.GtInt(10)),
+	).
+		OrderBy(u.USER_ID.Desc()).
+		Limit(4).
+		Selectx(func(row *Row) {
+			userID = row.Int(u.USER_ID)
+		}, func() {
+			userIDs = append(userIDs, userID)
+		}).
+		Fetch(db)
+	is.NoErr(err)
+	is.True(len(userIDs) <= 4)
+	for i := 1; i < len(userIDs); i++ {
+		is.True(userIDs[i-1] > userIDs[i])
+	}
+}
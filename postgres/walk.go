@@ -0,0 +1,296 @@
+package sq
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Literal is a literal argument reported by Walk i.e. a value that is sent to
+// the database as a query arg, such as the 5 in 'u.user_id = $1'.
+type Literal struct {
+	Value interface{}
+}
+
+// Walk traverses a node depth first, calling visit for every Query, Table,
+// Field, Predicate and Assignment it contains as well as every Literal
+// argument. The node is usually a Query but it can be any of the above. If
+// visit returns false, the children of that node are skipped.
+//
+// CTEs and Subqueries are reported as Tables whose children are their
+// queries. A CTE's query is only traversed the first time the CTE is seen, so
+// recursive CTEs do not loop forever. The tables written to by an INSERT,
+// UPDATE or DELETE are its IntoTable, UpdateTable and FromTable respectively,
+// which are always the first Table reported after the query (and its CTEs).
+//
+// Every Predicate is also a Field and every Field also satisfies the Table
+// interface, so when type switching on the nodes check for Predicate before
+// Field before Table.
+//
+// Walk only sees what the query holds before it is run: fields that a
+// Selectx mapper would add to the query are not reported.
+func Walk(node interface{}, visit func(node interface{}) bool) {
+	w := &walker{
+		visit:    visit,
+		seenCTEs: map[string]bool{},
+	}
+	w.walk(node)
+}
+
+type walker struct {
+	visit    func(node interface{}) bool
+	seenCTEs map[string]bool
+}
+
+func (w *walker) walk(node interface{}) {
+	if p, ok := node.(VariadicPredicate); ok && len(p.Predicates) == 0 {
+		return // an empty WHERE or HAVING
+	}
+	switch n := node.(type) {
+	case nil:
+		return
+	case *SelectQuery:
+		if n != nil {
+			w.walk(*n)
+		}
+		return
+	case Fields:
+		for _, field := range n {
+			w.walk(field)
+		}
+		return
+	case Assignments:
+		for _, assignment := range n {
+			w.walk(assignment)
+		}
+		return
+	case JoinTables:
+		for _, joinTable := range n {
+			w.walk(joinTable)
+		}
+		return
+	case Windows:
+		for _, window := range n {
+			w.walk(window)
+		}
+		return
+	case RowValues:
+		for _, rowValue := range n {
+			w.walk(rowValue)
+		}
+		return
+	case []CTE:
+		for _, cte := range n {
+			w.walk(cte)
+		}
+		return
+	case []Query:
+		for _, query := range n {
+			w.walk(query)
+		}
+		return
+	case Literal:
+		w.visit(n)
+		return
+	case ArrayParameter:
+		w.visit(Literal{Value: n.value})
+		return
+	case uuidList:
+		w.walkValues(n)
+		return
+	case interface {
+		AppendSQLExclude(*strings.Builder, *[]interface{}, map[string]int, []string)
+	}, interface {
+		AppendSQL(*strings.Builder, *[]interface{}, map[string]int)
+	}:
+		if w.visit(node) {
+			w.walkChildren(node)
+		}
+		return
+	}
+	// Slices are expanded into one arg per element, the same way
+	// appendSQLValue does.
+	if v := reflect.ValueOf(node); v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < v.Len(); i++ {
+			w.walk(v.Index(i).Interface())
+		}
+		return
+	}
+	w.visit(Literal{Value: node})
+}
+
+func (w *walker) walkValues(values []interface{}) {
+	for _, value := range values {
+		w.walk(value)
+	}
+}
+
+func (w *walker) walkChildren(node interface{}) {
+	switch n := node.(type) {
+	// Queries
+	case SelectQuery:
+		w.walk(n.CTEs)
+		w.walk(n.SelectFields)
+		w.walk(n.DistinctOn)
+		w.walk(n.FromTable)
+		w.walk(n.JoinTables)
+		w.walk(n.WherePredicate)
+		w.walk(n.GroupByFields)
+		w.walk(n.HavingPredicate)
+		w.walk(n.Windows)
+		w.walk(n.OrderByFields)
+		if n.LimitValue != nil {
+			w.walk(*n.LimitValue)
+		}
+		if n.OffsetValue != nil {
+			w.walk(*n.OffsetValue)
+		}
+	case InsertQuery:
+		w.walk(n.CTEs)
+		w.walk(n.IntoTable)
+		w.walk(n.InsertColumns)
+		w.walk(n.RowValues)
+		w.walk(n.SelectQuery)
+		w.walk(n.ConflictFields)
+		w.walk(n.ConflictPredicate)
+		w.walk(n.Resolution)
+		w.walk(n.ResolutionPredicate)
+		w.walk(n.ReturningFields)
+	case UpdateQuery:
+		w.walk(n.CTEs)
+		w.walk(n.UpdateTable)
+		w.walk(n.Assignments)
+		w.walk(n.FromTable)
+		w.walk(n.JoinTables)
+		w.walk(n.WherePredicate)
+		w.walk(n.ReturningFields)
+	case DeleteQuery:
+		w.walk(n.CTEs)
+		w.walk(n.FromTable)
+		w.walk(n.UsingTable)
+		w.walk(n.JoinTables)
+		w.walk(n.WherePredicate)
+		w.walk(n.ReturningFields)
+	case VariadicQuery:
+		w.walk(n.Queries)
+		w.walk(n.OrderByFields)
+		if n.LimitValue != nil {
+			w.walk(*n.LimitValue)
+		}
+		if n.OffsetValue != nil {
+			w.walk(*n.OffsetValue)
+		}
+	case ValuesQuery:
+		w.walk(n.RowValues)
+
+	// Tables
+	case CTE:
+		name := n.GetName()
+		if w.seenCTEs[name] {
+			return
+		}
+		w.seenCTEs[name] = true
+		w.walk(n.GetQuery())
+	case IntermediateCTE:
+		w.walkChildren(CTE(n))
+	case Subquery:
+		w.walk(n.GetQuery())
+	case FunctionInfo:
+		w.walkValues(n.Arguments)
+	case JoinTable:
+		w.walk(n.Table)
+		w.walk(n.OnPredicates)
+
+	// Fields
+	case ArrayField:
+		if n.format != nil {
+			w.walkValues(n.values)
+		} else if n.value != nil {
+			w.walk(n.value)
+		}
+	case BinaryField:
+		if n.value != nil {
+			w.walk(*n.value)
+		}
+	case BooleanField:
+		if n.format != nil {
+			w.walkValues(n.values)
+		} else if n.value != nil {
+			w.walk(*n.value)
+		}
+	case JSONField:
+		if n.value != nil {
+			w.visit(Literal{Value: n.value})
+		}
+	case NumberField:
+		if n.format != nil {
+			w.walkValues(n.values)
+		} else if n.value != nil {
+			w.walk(n.value)
+		}
+	case StringField:
+		if n.format != nil {
+			w.walkValues(n.values)
+		} else if n.value != nil {
+			w.walk(*n.value)
+		}
+	case TimeField:
+		if n.format != nil {
+			w.walkValues(n.values)
+		} else if n.value != nil {
+			w.walk(*n.value)
+		}
+	case UUIDField:
+		if n.format != nil {
+			w.walkValues(n.values)
+		} else if n.value != nil {
+			w.walk(*n.value)
+		}
+	case RangeField:
+		w.walkValues(n.values)
+	case TSQueryField:
+		if n.format != nil {
+			w.walkValues(n.values)
+		} else if n.value != nil {
+			w.walk(*n.value)
+		}
+	case TSVectorField:
+		w.walkValues(n.values)
+	case CustomField:
+		w.walkValues(n.Values)
+	case PredicateCases:
+		for _, c := range n.Cases {
+			w.walk(c.Condition)
+			w.walk(c.Result)
+		}
+		w.walk(n.Fallback)
+	case SimpleCases:
+		w.walk(n.Expression)
+		for _, c := range n.Cases {
+			w.walk(c.Value)
+			w.walk(c.Result)
+		}
+		w.walk(n.Fallback)
+	case JSONAggregate:
+		w.walk(n.query)
+	case Window:
+		w.walk(n.PartitionByFields)
+		w.walk(n.OrderByFields)
+	case RowValue:
+		w.walkValues(n)
+
+	// Predicates
+	case CustomPredicate:
+		w.walkValues(n.Values)
+	case VariadicPredicate:
+		for _, predicate := range n.Predicates {
+			w.walk(predicate)
+		}
+
+	// Assignments
+	case FieldAssignment:
+		w.walk(n.Field)
+		w.walk(n.Value)
+	case CustomAssignment:
+		w.walkValues(n.Values)
+	}
+}
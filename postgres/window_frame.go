@@ -0,0 +1,197 @@
+package sq
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FrameBound represents the start or end of a WindowFrame e.g. 'UNBOUNDED
+// PRECEDING', '5 PRECEDING' or 'CURRENT ROW'.
+type FrameBound struct {
+	// position is one of "UNBOUNDED PRECEDING", "PRECEDING", "CURRENT ROW",
+	// "FOLLOWING" or "UNBOUNDED FOLLOWING"
+	position string
+	// offset is the rendered offset of a PRECEDING or FOLLOWING bound
+	offset string
+	// interval indicates if the offset is an interval, which is only valid
+	// in RANGE mode
+	interval bool
+}
+
+// UnboundedPreceding represents the 'UNBOUNDED PRECEDING' frame bound i.e. the
+// first row of the partition.
+func UnboundedPreceding() FrameBound {
+	return FrameBound{position: "UNBOUNDED PRECEDING"}
+}
+
+// UnboundedFollowing represents the 'UNBOUNDED FOLLOWING' frame bound i.e. the
+// last row of the partition.
+func UnboundedFollowing() FrameBound {
+	return FrameBound{position: "UNBOUNDED FOLLOWING"}
+}
+
+// CurrentRow represents the 'CURRENT ROW' frame bound.
+func CurrentRow() FrameBound {
+	return FrameBound{position: "CURRENT ROW"}
+}
+
+// Preceding represents the 'offset PRECEDING' frame bound. The offset is
+// either an int (a number of rows, groups or a numeric distance) or an
+// Interval/time.Duration (a distance for RANGE frames on time columns). It
+// panics if the offset is of any other type.
+func Preceding(offset interface{}) FrameBound {
+	return frameOffset("PRECEDING", offset)
+}
+
+// Following represents the 'offset FOLLOWING' frame bound. The offset is
+// either an int (a number of rows, groups or a numeric distance) or an
+// Interval/time.Duration (a distance for RANGE frames on time columns). It
+// panics if the offset is of any other type.
+func Following(offset interface{}) FrameBound {
+	return frameOffset("FOLLOWING", offset)
+}
+
+// frameOffset renders the offset of a PRECEDING or FOLLOWING frame bound. The
+// offset is written into the query directly because Postgres cannot always
+// infer the type of a parameter in a frame clause, which is safe as it is
+// built from numbers only.
+func frameOffset(position string, offset interface{}) FrameBound {
+	bound := FrameBound{position: position}
+	switch v := offset.(type) {
+	case int:
+		bound.offset = strconv.Itoa(v)
+	case int64:
+		bound.offset = strconv.FormatInt(v, 10)
+	case time.Duration:
+		return frameOffset(position, Interval{Duration: v})
+	case Interval:
+		if v.Years < 0 || v.Months < 0 || v.Days < 0 || v.Duration < 0 {
+			panic(fmt.Errorf("sq: frame offset %#v must not be negative", offset))
+		}
+		bound.offset, bound.interval = "'"+v.String()+"'::interval", true
+	default:
+		panic(fmt.Errorf("sq: unsupported frame offset %#v", offset))
+	}
+	if strings.HasPrefix(bound.offset, "-") {
+		panic(fmt.Errorf("sq: frame offset %#v must not be negative", offset))
+	}
+	return bound
+}
+
+// String returns the SQL representation of the FrameBound.
+func (b FrameBound) String() string {
+	if b.offset == "" {
+		return b.position
+	}
+	return b.offset + " " + b.position
+}
+
+// WindowFrame represents the frame clause of a Window e.g. 'ROWS BETWEEN
+// UNBOUNDED PRECEDING AND CURRENT ROW EXCLUDE TIES'. Create one with
+// RowsFrame, RangeFrame or GroupsFrame and add it to a Window with
+// Window.WithFrame:
+//
+//	PartitionBy(tbl.A).OrderBy(tbl.B).WithFrame(RowsFrame().Between(Preceding(2), CurrentRow()))
+type WindowFrame struct {
+	mode      string
+	start     FrameBound
+	end       *FrameBound
+	exclusion string
+}
+
+// RowsFrame creates a new WindowFrame in ROWS mode, where offsets count rows.
+func RowsFrame() WindowFrame {
+	return WindowFrame{mode: "ROWS", start: UnboundedPreceding()}
+}
+
+// RangeFrame creates a new WindowFrame in RANGE mode, where offsets are a
+// distance from the current row's ORDER BY value.
+func RangeFrame() WindowFrame {
+	return WindowFrame{mode: "RANGE", start: UnboundedPreceding()}
+}
+
+// GroupsFrame creates a new WindowFrame in GROUPS mode, where offsets count
+// groups of peer rows.
+func GroupsFrame() WindowFrame {
+	return WindowFrame{mode: "GROUPS", start: UnboundedPreceding()}
+}
+
+// Start sets the start of the WindowFrame, with the end being the current row
+// i.e. 'ROWS start'. It panics if the bound is not valid as a start.
+func (f WindowFrame) Start(start FrameBound) WindowFrame {
+	f.validate(start, nil)
+	f.start, f.end = start, nil
+	return f
+}
+
+// Between sets the start and end of the WindowFrame i.e. 'ROWS BETWEEN start
+// AND end'. It panics if the bounds are not valid.
+func (f WindowFrame) Between(start, end FrameBound) WindowFrame {
+	f.validate(start, &end)
+	f.start, f.end = start, &end
+	return f
+}
+
+// validate panics if the start and end bounds are not valid for the
+// WindowFrame's mode.
+func (f WindowFrame) validate(start FrameBound, end *FrameBound) {
+	if start.position == "UNBOUNDED FOLLOWING" {
+		panic(fmt.Errorf("sq: a window frame cannot start at UNBOUNDED FOLLOWING"))
+	}
+	bounds := []FrameBound{start}
+	if end != nil {
+		if end.position == "UNBOUNDED PRECEDING" {
+			panic(fmt.Errorf("sq: a window frame cannot end at UNBOUNDED PRECEDING"))
+		}
+		bounds = append(bounds, *end)
+	}
+	for _, bound := range bounds {
+		if bound.interval && f.mode != "RANGE" {
+			panic(fmt.Errorf("sq: interval offset %s is only valid in RANGE mode", bound.offset))
+		}
+	}
+}
+
+// ExcludeCurrentRow returns a new WindowFrame that excludes the current row
+// from the frame i.e. 'EXCLUDE CURRENT ROW'.
+func (f WindowFrame) ExcludeCurrentRow() WindowFrame {
+	f.exclusion = "EXCLUDE CURRENT ROW"
+	return f
+}
+
+// ExcludeGroup returns a new WindowFrame that excludes the current row and its
+// peers from the frame i.e. 'EXCLUDE GROUP'.
+func (f WindowFrame) ExcludeGroup() WindowFrame {
+	f.exclusion = "EXCLUDE GROUP"
+	return f
+}
+
+// ExcludeTies returns a new WindowFrame that excludes the peers of the current
+// row, but not the current row itself, from the frame i.e. 'EXCLUDE TIES'.
+func (f WindowFrame) ExcludeTies() WindowFrame {
+	f.exclusion = "EXCLUDE TIES"
+	return f
+}
+
+// String returns the SQL representation of the WindowFrame.
+func (f WindowFrame) String() string {
+	buf := &strings.Builder{}
+	buf.WriteString(f.mode)
+	if f.end == nil {
+		buf.WriteString(" " + f.start.String())
+	} else {
+		buf.WriteString(" BETWEEN " + f.start.String() + " AND " + f.end.String())
+	}
+	if f.exclusion != "" {
+		buf.WriteString(" " + f.exclusion)
+	}
+	return buf.String()
+}
+
+// WithFrame sets the frame definition of the window to the WindowFrame.
+func (w Window) WithFrame(frame WindowFrame) Window {
+	w.FrameDefinition = frame.String()
+	return w
+}
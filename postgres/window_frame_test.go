@@ -0,0 +1,87 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestWindowFrame(t *testing.T) {
+	type TT struct {
+		description string
+		frame       WindowFrame
+		wantFrame   string
+	}
+	tests := []TT{
+		{"default", RowsFrame(), "ROWS UNBOUNDED PRECEDING"},
+		{"Start", RowsFrame().Start(Preceding(3)), "ROWS 3 PRECEDING"},
+		{"Between", RowsFrame().Between(UnboundedPreceding(), CurrentRow()), "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW"},
+		{"Following", RowsFrame().Between(CurrentRow(), Following(int64(2))), "ROWS BETWEEN CURRENT ROW AND 2 FOLLOWING"},
+		{"UnboundedFollowing", RangeFrame().Between(Preceding(5), UnboundedFollowing()), "RANGE BETWEEN 5 PRECEDING AND UNBOUNDED FOLLOWING"},
+		{
+			"Range interval",
+			RangeFrame().Between(Preceding(Interval{Days: 7}), Following(time.Hour)),
+			"RANGE BETWEEN '7 days'::interval PRECEDING AND '3600 seconds'::interval FOLLOWING",
+		},
+		{"Groups", GroupsFrame().Between(Preceding(1), Following(1)), "GROUPS BETWEEN 1 PRECEDING AND 1 FOLLOWING"},
+		{"ExcludeCurrentRow", RowsFrame().Between(Preceding(1), Following(1)).ExcludeCurrentRow(), "ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING EXCLUDE CURRENT ROW"},
+		{"ExcludeGroup", GroupsFrame().ExcludeGroup(), "GROUPS UNBOUNDED PRECEDING EXCLUDE GROUP"},
+		{"ExcludeTies", RangeFrame().Start(CurrentRow()).ExcludeTies(), "RANGE CURRENT ROW EXCLUDE TIES"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.wantFrame, tt.frame.String())
+		})
+	}
+}
+
+func TestWindowFrame_Panics(t *testing.T) {
+	tests := map[string]func(){
+		"start at UNBOUNDED FOLLOWING":   func() { RowsFrame().Start(UnboundedFollowing()) },
+		"end at UNBOUNDED PRECEDING":     func() { RowsFrame().Between(CurrentRow(), UnboundedPreceding()) },
+		"interval offset in ROWS mode":   func() { RowsFrame().Between(Preceding(time.Hour), CurrentRow()) },
+		"interval offset in GROUPS mode": func() { GroupsFrame().Start(Preceding(Interval{Days: 1})) },
+		"negative offset":                func() { RowsFrame().Start(Preceding(-1)) },
+		"negative interval offset":       func() { RangeFrame().Start(Preceding(-time.Hour)) },
+		"unsupported offset":             func() { Preceding("1; DROP TABLE users") },
+	}
+	for description, f := range tests {
+		f := f
+		t.Run(description, func(t *testing.T) {
+			is := is.New(t)
+			defer func() { is.True(recover() != nil) }()
+			f()
+		})
+	}
+}
+
+func TestWindowFrame_Over(t *testing.T) {
+	is := is.New(t)
+	ur := USER_ROLES().As("ur")
+	w := PartitionBy(ur.COHORT).OrderBy(ur.CREATED_AT).WithFrame(RangeFrame().Between(Preceding(Interval{Days: 7}), CurrentRow()))
+	buf := &strings.Builder{}
+	var args []interface{}
+	Fields{
+		CountOver(w),
+		StringAggOver(ur.ROLE, ", ", w),
+		ArrayAggOver(ur.ROLE, w),
+		BoolAndOver(ur.DELETED_AT.IsNull(), w),
+		BoolOrOver(ur.DELETED_AT.IsNull(), w),
+		StddevOver(ur.USER_ID, w),
+		VarianceOver(ur.USER_ID, w).Filter(ur.DELETED_AT.IsNull()),
+	}.AppendSQLExclude(buf, &args, nil, nil)
+	window := "(PARTITION BY ur.cohort ORDER BY ur.created_at RANGE BETWEEN '7 days'::interval PRECEDING AND CURRENT ROW)"
+	is.Equal("COUNT(*) OVER "+window+
+		", STRING_AGG(ur.role, ?) OVER "+window+
+		", ARRAY_AGG(ur.role) OVER "+window+
+		", BOOL_AND(ur.deleted_at IS NULL) OVER "+window+
+		", BOOL_OR(ur.deleted_at IS NULL) OVER "+window+
+		", STDDEV(ur.user_id) OVER "+window+
+		", VARIANCE(ur.user_id) FILTER (WHERE ur.deleted_at IS NULL) OVER "+window, buf.String())
+	is.Equal([]interface{}{", "}, args)
+}
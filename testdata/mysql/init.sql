@@ -437,7 +437,7 @@ CREATE TABLE sessions (
 );
 
 CREATE TABLE media (
-    uuid BINARY(16) NOT NULL PRIMARY KEY
+    uuid BINARY(16) NOT NULL PRIMARY KEY COMMENT 'sq:uuid'
     ,name VARCHAR(255) NOT NULL DEFAULT ''
     ,type VARCHAR(255) NOT NULL DEFAULT 'application/octet-stream'
     ,description VARCHAR(255) NOT NULL DEFAULT ''
@@ -447,6 +447,7 @@ CREATE TABLE media (
     ,deleted_at DATETIME
 
     ,FOREIGN KEY (type) REFERENCES mime_type_enum (type) ON UPDATE CASCADE
+    ,FULLTEXT (name, description)
 );
 DELIMITER $$
 CREATE TRIGGER before_insert_media BEFORE INSERT ON media FOR EACH ROW